@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type GetWorkPackageActivitiesArguments struct {
+	ConnectionArguments
+	WorkPackageID int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	CommentFormat string `json:"comment_format,omitempty" jsonschema:"enum=raw,enum=html,description=Format of the returned comments; html resolves @mentions and work package links to names (default raw)"`
+}
+
+func (a GetWorkPackageActivitiesArguments) validate() error {
+	return errors.Join(positiveID("work_package_id", a.WorkPackageID), validCommentFormat(a.CommentFormat))
+}
+
+type Activity struct {
+	ID        int      `json:"id"`
+	Author    string   `json:"author"`
+	CreatedAt string   `json:"createdAt"`
+	Comment   string   `json:"comment,omitempty"`
+	Details   []string `json:"details,omitempty"`
+}
+
+// getWorkPackageActivities returns the full journal of a work package, oldest first:
+// comments as well as field changes
+func (c *openProjectClient) getWorkPackageActivities(ctx context.Context, arguments GetWorkPackageActivitiesArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[ActivityResponse](ctx, c, fmt.Sprintf("/api/v3/work_packages/%d/activities", arguments.WorkPackageID))
+	if err != nil {
+		return nil, err
+	}
+	activities := make([]Activity, 0, len(elements))
+	for _, a := range elements {
+		comment, err := a.Comment.Text(arguments.CommentFormat)
+		if err != nil {
+			return nil, err
+		}
+		activity := Activity{ID: a.ID, Author: a.Links.User.Title, CreatedAt: a.CreatedAt, Comment: comment}
+		for _, detail := range a.Details {
+			activity.Details = append(activity.Details, detail.Raw)
+		}
+		activities = append(activities, activity)
+	}
+	return jsonResponse(activities)
+}
@@ -0,0 +1,202 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type DownloadAttachmentArguments struct {
+	ConnectionArguments
+	AttachmentID int `json:"attachment_id" jsonschema:"required,description=Attachment ID of OpenProject (see the attachments of get_detail_work_package_by_id)"`
+}
+
+func (a DownloadAttachmentArguments) validate() error {
+	return positiveID("attachment_id", a.AttachmentID)
+}
+
+type ListAttachmentsArguments struct {
+	ConnectionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+}
+
+func (a ListAttachmentsArguments) validate() error {
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+func toAttachment(a AttachmentResponse) Attachment {
+	return Attachment{
+		ID:          a.ID,
+		FileName:    a.FileName,
+		ContentType: a.ContentType,
+		FileSize:    a.FileSize,
+		Href:        a.Links.DownloadLocation.Href,
+	}
+}
+
+func (c *openProjectClient) listAttachments(ctx context.Context, arguments ListAttachmentsArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[AttachmentResponse](ctx, c, fmt.Sprintf("/api/v3/work_packages/%d/attachments", arguments.WorkPackageID))
+	if err != nil {
+		return nil, err
+	}
+	attachments := make([]Attachment, 0, len(elements))
+	for _, a := range elements {
+		attachments = append(attachments, toAttachment(a))
+	}
+	return jsonResponse(attachments)
+}
+
+type UploadAttachmentArguments struct {
+	ConnectionArguments
+	WorkPackageID int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	FileName      string `json:"file_name" jsonschema:"required,description=Name of the attached file,example=screenshot.png"`
+	ContentBase64 string `json:"content_base64" jsonschema:"required,description=File content encoded as standard base64"`
+	ContentType   string `json:"content_type,omitempty" jsonschema:"description=MIME type of the file (default detected from the content),example=image/png"`
+}
+
+func (a UploadAttachmentArguments) validate() error {
+	if strings.TrimSpace(a.FileName) == "" {
+		return fmt.Errorf("file_name must not be empty")
+	}
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+// quoteEscaper escapes a file name for a Content-Disposition header, as mime/multipart does
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// uploadAttachment sends OpenProject's multipart upload: a JSON "metadata" part
+// followed by the "file" part
+func (c *openProjectClient) uploadAttachment(ctx context.Context, arguments UploadAttachmentArguments) (*mcp_golang.ToolResponse, error) {
+	data, err := base64.StdEncoding.DecodeString(arguments.ContentBase64)
+	if err != nil {
+		return nil, fmt.Errorf("content_base64 is not valid base64: %w", err)
+	}
+	if int64(len(data)) > c.maxAttachmentSize {
+		return nil, fmt.Errorf("file is %d bytes, larger than --max-attachment-size (%d bytes)", len(data), c.maxAttachmentSize)
+	}
+	contentType := arguments.ContentType
+	if contentType == "" {
+		contentType = http.DetectContentType(data)
+	}
+
+	var body bytes.Buffer
+	form := multipart.NewWriter(&body)
+	metadata, err := json.Marshal(struct {
+		FileName string `json:"fileName"`
+	}{arguments.FileName})
+	if err != nil {
+		return nil, err
+	}
+	parts := []struct {
+		disposition, contentType string
+		data                     []byte
+	}{
+		{`form-data; name="metadata"`, "application/json", metadata},
+		{fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(arguments.FileName)), contentType, data},
+	}
+	for _, p := range parts {
+		header := make(textproto.MIMEHeader)
+		header.Set("Content-Disposition", p.disposition)
+		header.Set("Content-Type", p.contentType)
+		part, err := form.CreatePart(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := part.Write(p.data); err != nil {
+			return nil, err
+		}
+	}
+	if err := form.Close(); err != nil {
+		return nil, err
+	}
+
+	var attachment AttachmentResponse
+	path := fmt.Sprintf("/api/v3/work_packages/%d/attachments", arguments.WorkPackageID)
+	if err := c.doBody(ctx, "POST", path, body.Bytes(), form.FormDataContentType(), &attachment); err != nil {
+		return nil, err
+	}
+	return jsonResponse(toAttachment(attachment))
+}
+
+func (c *openProjectClient) downloadAttachment(ctx context.Context, arguments DownloadAttachmentArguments) (*mcp_golang.ToolResponse, error) {
+	var attachment AttachmentResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v3/attachments/%d", arguments.AttachmentID), &attachment); err != nil {
+		return nil, err
+	}
+	if attachment.FileSize > c.maxAttachmentSize {
+		return nil, fmt.Errorf("attachment %d is %d bytes, larger than --max-attachment-size (%d bytes)", arguments.AttachmentID, attachment.FileSize, c.maxAttachmentSize)
+	}
+
+	data, contentType, err := c.download(ctx, attachment.Links.DownloadLocation.Href)
+	if err != nil {
+		return nil, err
+	}
+	if contentType == "" {
+		contentType = attachment.ContentType
+	}
+	return jsonResponse(struct {
+		ID            int    `json:"id"`
+		FileName      string `json:"fileName"`
+		ContentType   string `json:"contentType"`
+		Size          int    `json:"size"`
+		ContentBase64 string `json:"contentBase64"`
+	}{attachment.ID, attachment.FileName, contentType, len(data), base64.StdEncoding.EncodeToString(data)})
+}
+
+// download fetches the raw bytes behind href, at most c.maxAttachmentSize of them.
+// Credentials are only sent when href points at the OpenProject instance itself
+// (attachments may be served from external storage with a signed URL).
+func (c *openProjectClient) download(ctx context.Context, href string) ([]byte, string, error) {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	base, err := url.Parse(c.baseURL)
+	if err != nil {
+		return nil, "", err
+	}
+	// hrefs are absolute paths that already include any base path of the instance
+	target, err := base.Parse(href)
+	if err != nil {
+		return nil, "", err
+	}
+	req, err := http.NewRequestWithContext(ctx, "GET", target.String(), nil)
+	if err != nil {
+		return nil, "", err
+	}
+	httpClient := c.httpClient
+	if target.Host == base.Host {
+		c.setHeaders(req, "*/*", "")
+	} else {
+		req.Header.Set("Accept", "*/*")
+		httpClient = withoutCredentials(httpClient)
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, "", err
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, "", err
+	}
+	defer resp.Body.Close()
+	data, err := io.ReadAll(io.LimitReader(resp.Body, c.maxAttachmentSize+1))
+	if err != nil {
+		return nil, "", err
+	}
+	if err := checkResponse(resp, data); err != nil {
+		return nil, "", err
+	}
+	if int64(len(data)) > c.maxAttachmentSize {
+		return nil, "", fmt.Errorf("attachment is larger than --max-attachment-size (%d bytes)", c.maxAttachmentSize)
+	}
+	return data, resp.Header.Get("Content-Type"), nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"golang.org/x/oauth2"
+	"golang.org/x/oauth2/clientcredentials"
+)
+
+// oauth2Options holds the --oauth-* flags used with --auth-mode=oauth2
+type oauth2Options struct {
+	tokenURL     string
+	clientID     string
+	clientSecret string
+}
+
+func (o oauth2Options) validate() error {
+	if o.tokenURL == "" || o.clientID == "" || o.clientSecret == "" {
+		return fmt.Errorf("--auth-mode=oauth2 requires --oauth-token-url, --oauth-client-id and --oauth-client-secret")
+	}
+	return nil
+}
+
+// newOAuth2Client wraps base so every request carries a client-credentials
+// bearer token; the token is fetched through base (proxy, CA settings) and
+// refreshed by the oauth2 transport when it expires
+func newOAuth2Client(base *http.Client, opts oauth2Options) *http.Client {
+	cfg := clientcredentials.Config{
+		ClientID:     opts.clientID,
+		ClientSecret: opts.clientSecret,
+		TokenURL:     opts.tokenURL,
+		Scopes:       []string{"api_v3"},
+	}
+	ctx := context.WithValue(context.Background(), oauth2.HTTPClient, base)
+	client := cfg.Client(ctx)
+	client.Timeout = base.Timeout
+	return client
+}
+
+// withoutCredentials returns a client that does not add the OAuth2 bearer token,
+// for requests leaving the OpenProject host
+func withoutCredentials(client *http.Client) *http.Client {
+	if t, ok := client.Transport.(*oauth2.Transport); ok {
+		return &http.Client{Transport: t.Base, Timeout: client.Timeout}
+	}
+	return client
+}
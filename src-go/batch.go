@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type GetWorkPackagesArguments struct {
+	ConnectionArguments
+	WorkPackageIDs    []int  `json:"work_package_ids" jsonschema:"required,description=Work Package IDs of OpenProject; results keep this order"`
+	DescriptionFormat string `json:"description_format,omitempty" jsonschema:"enum=raw,enum=html,enum=plain,description=Format of the returned descriptions (default raw)"`
+}
+
+func (a GetWorkPackagesArguments) validate() error {
+	if len(a.WorkPackageIDs) == 0 {
+		return fmt.Errorf("work_package_ids must not be empty")
+	}
+	for _, id := range a.WorkPackageIDs {
+		if id <= 0 {
+			return fmt.Errorf("work_package_ids must only contain positive integers, got %d", id)
+		}
+	}
+	return nil
+}
+
+// BatchResult is one entry of get_work_packages: either the work package or why it could not be fetched
+type BatchResult struct {
+	ID          int                 `json:"id"`
+	WorkPackage *MinimalWorkPackage `json:"workPackage,omitempty"`
+	Error       string              `json:"error,omitempty"`
+}
+
+// getWorkPackages fetches the IDs with at most c.concurrency requests in flight,
+// returning results in input order
+func (c *openProjectClient) getWorkPackages(ctx context.Context, arguments GetWorkPackagesArguments) (*mcp_golang.ToolResponse, error) {
+	results := make([]BatchResult, len(arguments.WorkPackageIDs))
+	c.forEach(len(results), func(i int) {
+		id := arguments.WorkPackageIDs[i]
+		results[i].ID = id
+		wp, err := c.fetchMinimalWorkPackage(ctx, CurlToolArguments{WorkPackageID: id, DescriptionFormat: arguments.DescriptionFormat})
+		if err != nil {
+			results[i].Error = err.Error()
+			return
+		}
+		results[i].WorkPackage = wp
+	})
+	return jsonResponse(results)
+}
+
+type BulkUpdateStatusArguments struct {
+	ConnectionArguments
+	WorkPackageIDs []int `json:"work_package_ids" jsonschema:"required,description=Work Package IDs of OpenProject (each at most once); results keep this order"`
+	StatusID       int   `json:"status_id" jsonschema:"required,description=ID of the new status (see list_statuses)"`
+}
+
+func (a BulkUpdateStatusArguments) validate() error {
+	errs := []error{
+		GetWorkPackagesArguments{WorkPackageIDs: a.WorkPackageIDs}.validate(),
+		positiveID("status_id", a.StatusID),
+	}
+	// patching the same work package twice at once would race on its lockVersion
+	seen := make(map[int]bool, len(a.WorkPackageIDs))
+	for _, id := range a.WorkPackageIDs {
+		if seen[id] {
+			errs = append(errs, fmt.Errorf("work_package_ids lists %d more than once", id))
+		}
+		seen[id] = true
+	}
+	return errors.Join(errs...)
+}
+
+// BulkStatusResult is the outcome of one work package's status change: the new
+// status, the request --dry-run kept from being sent, or the error
+type BulkStatusResult struct {
+	ID     int            `json:"id"`
+	Status string         `json:"status,omitempty"`
+	DryRun *dryRunRequest `json:"dryRun,omitempty"`
+	Error  string         `json:"error,omitempty"`
+}
+
+// bulkUpdateStatus patches every work package independently, so one failure
+// (e.g. a workflow that forbids the transition) does not stop the others.
+// Results are returned in input order.
+func (c *openProjectClient) bulkUpdateStatus(ctx context.Context, arguments BulkUpdateStatusArguments) (*mcp_golang.ToolResponse, error) {
+	results := make([]BulkStatusResult, len(arguments.WorkPackageIDs))
+	c.forEach(len(results), func(i int) {
+		results[i].ID = arguments.WorkPackageIDs[i]
+		updated, err := c.patchWorkPackage(ctx, results[i].ID, WorkPackagePayload{
+			Links: map[string]*Link{
+				"status": {Href: fmt.Sprintf("/api/v3/statuses/%d", arguments.StatusID)},
+			},
+		})
+		// bind only reports a dry run as success for the call as a whole
+		if errors.As(err, &results[i].DryRun) {
+			return
+		}
+		if err != nil {
+			results[i].Error = err.Error()
+			return
+		}
+		results[i].Status = updated.Links.Status.Title
+	})
+	return jsonResponse(results)
+}
+
+// forEach calls fn for 0..n-1 with at most c.concurrency calls running at once
+func (c *openProjectClient) forEach(n int, fn func(i int)) {
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < min(c.concurrency, n); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				fn(i)
+			}
+		}()
+	}
+	for i := 0; i < n; i++ {
+		jobs <- i
+	}
+	close(jobs)
+	wg.Wait()
+}
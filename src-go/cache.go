@@ -0,0 +1,104 @@
+package main
+
+import (
+	"sync"
+	"time"
+)
+
+const (
+	// how long past its TTL an entry with an ETag is kept for revalidation
+	staleGracePeriod = 10 * time.Minute
+	// a full cache evicts before adding another entry
+	maxCacheEntries = 1000
+)
+
+// responseCache is an in-memory TTL cache of response bodies, safe for the
+// concurrent tool calls MCP may dispatch
+type responseCache struct {
+	ttl time.Duration
+
+	mu      sync.Mutex
+	entries map[string]cacheEntry
+}
+
+type cacheEntry struct {
+	body []byte
+	// empty when the server sent no ETag
+	etag    string
+	expires time.Time
+}
+
+func newResponseCache(ttl time.Duration) *responseCache {
+	return &responseCache{ttl: ttl, entries: make(map[string]cacheEntry)}
+}
+
+// lookup returns the entry for key and whether it is still fresh. Expired
+// entries are kept only if they have an ETag to revalidate them with, and
+// for at most staleGracePeriod.
+func (rc *responseCache) lookup(key string) (entry cacheEntry, fresh bool, ok bool) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	entry, ok = rc.entries[key]
+	if !ok {
+		return cacheEntry{}, false, false
+	}
+	if now := time.Now(); now.After(entry.expires) {
+		if entry.dead(now) {
+			delete(rc.entries, key)
+			return cacheEntry{}, false, false
+		}
+		return entry, false, true
+	}
+	return entry, true, true
+}
+
+func (rc *responseCache) put(key string, body []byte, etag string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if _, ok := rc.entries[key]; !ok && len(rc.entries) >= maxCacheEntries {
+		rc.evict()
+	}
+	rc.entries[key] = cacheEntry{body: body, etag: etag, expires: time.Now().Add(rc.ttl)}
+}
+
+// dead reports whether an entry can no longer be served or revalidated
+func (e cacheEntry) dead(now time.Time) bool {
+	return now.After(e.expires) && (e.etag == "" || now.After(e.expires.Add(staleGracePeriod)))
+}
+
+// evict drops dead entries or, if there are none, the entry expiring first.
+// The caller holds rc.mu.
+func (rc *responseCache) evict() {
+	now := time.Now()
+	oldestKey := ""
+	var oldest time.Time
+	for key, entry := range rc.entries {
+		if entry.dead(now) {
+			delete(rc.entries, key)
+			continue
+		}
+		if oldestKey == "" || entry.expires.Before(oldest) {
+			oldestKey, oldest = key, entry.expires
+		}
+	}
+	if len(rc.entries) >= maxCacheEntries {
+		delete(rc.entries, oldestKey)
+	}
+}
+
+// refresh restarts the TTL of an entry the server confirmed as unchanged
+func (rc *responseCache) refresh(key string) {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	if entry, ok := rc.entries[key]; ok {
+		entry.expires = time.Now().Add(rc.ttl)
+		rc.entries[key] = entry
+	}
+}
+
+// clear drops every entry; called after writes so no tool serves stale data
+func (rc *responseCache) clear() {
+	rc.mu.Lock()
+	defer rc.mu.Unlock()
+	clear(rc.entries)
+}
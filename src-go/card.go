@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+const defaultCardExcerptLength = 120
+
+type WorkPackageCardArguments struct {
+	ConnectionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	ExcerptLength int `json:"excerpt_length,omitempty" jsonschema:"description=Maximum characters of the description excerpt (default 120),minimum=1,maximum=1000"`
+}
+
+func (a WorkPackageCardArguments) validate() error {
+	var err error
+	if a.ExcerptLength < 0 || a.ExcerptLength > 1000 {
+		err = fmt.Errorf("excerpt_length must be between 1 and 1000")
+	}
+	return errors.Join(positiveID("work_package_id", a.WorkPackageID), err)
+}
+
+// workPackageCard renders a terse three-line summary meant for pasting into
+// chat or another ticket, unlike the complete output of get_detail_work_package_by_id
+func (c *openProjectClient) workPackageCard(ctx context.Context, arguments WorkPackageCardArguments) (*mcp_golang.ToolResponse, error) {
+	wp, err := c.fetchMinimalWorkPackage(ctx, CurlToolArguments{WorkPackageID: arguments.WorkPackageID, CleanDescription: true})
+	if err != nil {
+		return nil, err
+	}
+	excerptLength := arguments.ExcerptLength
+	if excerptLength == 0 {
+		excerptLength = defaultCardExcerptLength
+	}
+	return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(renderWorkPackageCard(wp, excerptLength))), nil
+}
+
+// renderWorkPackageCard always prints the same fields in the same order, with
+// placeholders for unset ones, so cards read alike
+func renderWorkPackageCard(wp *MinimalWorkPackage, excerptLength int) string {
+	orNone := func(value, none string) string {
+		if value == "" {
+			return none
+		}
+		return value
+	}
+	var b strings.Builder
+	fmt.Fprintf(&b, "#%d [%s] %s\n", wp.ID, orNone(wp.Type, "?"), wp.Subject)
+	fmt.Fprintf(&b, "Status: %s | Assignee: %s | Priority: %s | Due: %s\n",
+		orNone(wp.Status, "?"), orNone(wp.Assignee, "Unassigned"), orNone(wp.Priority, "?"), orNone(wp.DueDate, "none"))
+	b.WriteString(orNone(excerpt(wp.Description, excerptLength), "(no description)"))
+	return b.String()
+}
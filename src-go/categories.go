@@ -0,0 +1,76 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListCategoriesArguments struct {
+	ConnectionArguments
+	ProjectID int `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+}
+
+func (a ListCategoriesArguments) validate() error {
+	return positiveID("project_id", a.ProjectID)
+}
+
+type SetCategoryArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	CategoryID    int `json:"category_id" jsonschema:"required,description=ID of the category (see list_categories)\\, or 0 to clear it"`
+}
+
+func (a SetCategoryArguments) validate() error {
+	return errors.Join(
+		positiveID("work_package_id", a.WorkPackageID),
+		optionalID("category_id", a.CategoryID),
+	)
+}
+
+type CategoryResponse struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Links struct {
+		DefaultAssignee Link `json:"defaultAssignee"`
+	} `json:"_links"`
+}
+
+type Category struct {
+	ID              int    `json:"id"`
+	Name            string `json:"name"`
+	DefaultAssignee string `json:"defaultAssignee,omitempty"`
+}
+
+func (c *openProjectClient) listCategories(ctx context.Context, arguments ListCategoriesArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[CategoryResponse](ctx, c, fmt.Sprintf("/api/v3/projects/%d/categories", arguments.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+	categories := make([]Category, 0, len(elements))
+	for _, cat := range elements {
+		categories = append(categories, Category{ID: cat.ID, Name: cat.Name, DefaultAssignee: cat.Links.DefaultAssignee.Title})
+	}
+	return jsonResponse(categories)
+}
+
+func (c *openProjectClient) setCategory(ctx context.Context, arguments SetCategoryArguments) (*mcp_golang.ToolResponse, error) {
+	category := &Link{}
+	if arguments.CategoryID != 0 {
+		category.Href = fmt.Sprintf("/api/v3/categories/%d", arguments.CategoryID)
+	}
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, WorkPackagePayload{
+		LockVersion: arguments.LockVersion,
+		Links:       map[string]*Link{"category": category},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID       int    `json:"id"`
+		Category string `json:"category"`
+	}{updated.ID, updated.Links.Category.Title})
+}
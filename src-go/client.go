@@ -0,0 +1,605 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"log/slog"
+	"math/rand"
+	"mime"
+	"net/http"
+	"net/url"
+	"os"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+	"golang.org/x/time/rate"
+)
+
+// OpenProject error payload, returned with `_type: "Error"` on non-2xx responses
+type APIErrorResponse struct {
+	Type            string `json:"_type"`
+	ErrorIdentifier string `json:"errorIdentifier"`
+	Message         string `json:"message"`
+	Embedded        struct {
+		// validation failures (422): the attribute, or one entry per failed attribute
+		Details struct {
+			Attribute string `json:"attribute"`
+		} `json:"details"`
+		Errors []APIErrorResponse `json:"errors"`
+	} `json:"_embedded"`
+}
+
+// validationMessage lists every failed attribute, e.g.
+// "subject: Subject can't be blank.; startDate: Start date must be before the finish date."
+func (r APIErrorResponse) validationMessage() string {
+	errs := r.Embedded.Errors
+	if len(errs) == 0 {
+		errs = []APIErrorResponse{r}
+	}
+	messages := make([]string, 0, len(errs))
+	for _, e := range errs {
+		if attribute := e.Embedded.Details.Attribute; attribute != "" {
+			messages = append(messages, attribute+": "+e.Message)
+		} else {
+			messages = append(messages, e.Message)
+		}
+	}
+	return strings.Join(messages, "; ")
+}
+
+// attributes are the names of the attributes a validation failure rejected
+func (r APIErrorResponse) attributes() []string {
+	errs := r.Embedded.Errors
+	if len(errs) == 0 {
+		errs = []APIErrorResponse{r}
+	}
+	var attributes []string
+	for _, e := range errs {
+		if attribute := e.Embedded.Details.Attribute; attribute != "" {
+			attributes = append(attributes, attribute)
+		}
+	}
+	return attributes
+}
+
+// openProjectClient talks to the API v3 of a single OpenProject instance
+type openProjectClient struct {
+	baseURL    string
+	basicAuth  string
+	httpClient *http.Client
+	timeout    time.Duration
+	// hard cap on the number of pages fetchAll follows
+	maxPages   int
+	maxRetries int
+	// nil when --cache-ttl is 0
+	cache             *responseCache
+	maxAttachmentSize int64
+	maxResponseBytes  int64
+	// --dry-run: non-GET requests are logged and answered with a dryRunRequest instead of being sent
+	dryRun bool
+	// --strict: check decoded resources look like what was asked for, see decode
+	strict bool
+	// --select: ask collections for only the properties a tool returns, see getSelected
+	selectFields bool
+	metrics      *serverMetrics
+	// maximum parallel requests of batch tools
+	concurrency int
+	// --rate-limit, shared by all tools and per-call clients; nil when unlimited
+	limiter *rate.Limiter
+	// named instances from the config file, selected with the instance argument
+	instances map[string]instance
+	// logger writes to stderr (or is discarded); stdout is reserved for the stdio transport
+	logger *log.Logger
+	// --log-format=json with --verbose: structured request logs, see logRequest
+	requestLogger *slog.Logger
+	// --verbose: tool errors also carry the call's correlation ID
+	verbose bool
+}
+
+// instance is a named OpenProject server from the config file
+type instance struct {
+	baseURL   string
+	basicAuth string
+}
+
+// wait blocks until the rate limiter allows another request, failing early if
+// the context deadline would pass first
+func (c *openProjectClient) wait(ctx context.Context) error {
+	if c.limiter == nil {
+		return nil
+	}
+	if err := c.limiter.Wait(ctx); err != nil {
+		return fmt.Errorf("waiting for --rate-limit: %w", err)
+	}
+	return nil
+}
+
+// basicAuthHeader builds the Authorization header OpenProject expects for an API key
+func basicAuthHeader(apiKey string) string {
+	// b64 encode "apikey:${apiKey}"
+	return "Basic " + base64.StdEncoding.EncodeToString([]byte("apikey:"+apiKey))
+}
+
+// transportOptions configures how the shared HTTP client reaches OpenProject
+type transportOptions struct {
+	// proxy URL; empty means the HTTP(S)_PROXY environment variables
+	proxy string
+	// PEM bundle trusted in addition to the system roots
+	caCertPath string
+	insecure   bool
+}
+
+// newHTTPClient builds the single HTTP client shared by all tools, so repeated
+// calls to the same OpenProject host reuse keep-alive connections
+func newHTTPClient(timeout time.Duration, opts transportOptions) (*http.Client, error) {
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.MaxIdleConns = 100
+	transport.MaxIdleConnsPerHost = 10
+	transport.IdleConnTimeout = 90 * time.Second
+	if opts.proxy != "" {
+		proxyURL, err := url.Parse(opts.proxy)
+		if err != nil {
+			return nil, fmt.Errorf("invalid proxy URL: %w", err)
+		}
+		transport.Proxy = http.ProxyURL(proxyURL)
+	}
+	if opts.caCertPath != "" || opts.insecure {
+		tlsConfig := &tls.Config{InsecureSkipVerify: opts.insecure}
+		if opts.caCertPath != "" {
+			pem, err := os.ReadFile(opts.caCertPath)
+			if err != nil {
+				return nil, fmt.Errorf("reading CA certificate: %w", err)
+			}
+			pool, err := x509.SystemCertPool()
+			if err != nil {
+				pool = x509.NewCertPool()
+			}
+			if !pool.AppendCertsFromPEM(pem) {
+				return nil, fmt.Errorf("no PEM certificates found in %s", opts.caCertPath)
+			}
+			tlsConfig.RootCAs = pool
+		}
+		transport.TLSClientConfig = tlsConfig
+	}
+	return &http.Client{Timeout: timeout, Transport: transport}, nil
+}
+
+// get fetches path (relative to the base URL, e.g. /api/v3/...) and decodes the JSON body into out
+func (c *openProjectClient) get(ctx context.Context, path string, out any) error {
+	return c.do(ctx, "GET", path, nil, out)
+}
+
+// getCached is get backed by the response cache, when enabled. Entries are keyed
+// by instance and credentials as well as path so per-call API keys never share responses.
+// An expired entry with an ETag is revalidated with If-None-Match, so an unchanged
+// resource costs a 304 instead of the whole body.
+func (c *openProjectClient) getCached(ctx context.Context, path string, out any) error {
+	if c.cache == nil {
+		return c.get(ctx, path, out)
+	}
+	key := c.baseURL + " " + c.basicAuth + " " + path
+	entry, fresh, ok := c.cache.lookup(key)
+	if fresh {
+		c.logf(ctx, "GET %s served from cache", path)
+		return c.decode(path, entry.body, out)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+	var header http.Header
+	if ok {
+		header = http.Header{"If-None-Match": {entry.etag}}
+	}
+	resp, body, err := c.roundTrip(ctx, "GET", path, nil, "", header)
+	if err != nil {
+		return err
+	}
+	if ok && resp.StatusCode == http.StatusNotModified {
+		c.logf(ctx, "GET %s not modified, served from cache", path)
+		c.cache.refresh(key)
+		return c.decode(path, entry.body, out)
+	}
+	var raw json.RawMessage
+	if err := c.readBody("GET", path, resp, body, &raw); err != nil {
+		return err
+	}
+	c.cache.put(key, raw, resp.Header.Get("ETag"))
+	return c.decode(path, raw, out)
+}
+
+// getSelected is get for a single collection page (path already has a query),
+// asking OpenProject via select for just the given properties. The select
+// parameter only exists on collections, and instances that reject it with a 400
+// are asked again for everything.
+func (c *openProjectClient) getSelected(ctx context.Context, path string, fields []string, out any) error {
+	if !c.selectFields {
+		return c.get(ctx, path, out)
+	}
+	err := c.get(ctx, path+"&select="+url.QueryEscape(strings.Join(fields, ",")), out)
+	if isStatus(err, http.StatusBadRequest) {
+		c.logf(ctx, "GET %s: select rejected, fetching all properties", path)
+		return c.get(ctx, path, out)
+	}
+	return err
+}
+
+// halResource is implemented by responses whose shape --strict verifies
+type halResource interface {
+	halType() string
+}
+
+// decode unmarshals body into out. In strict mode a halResource must also carry
+// an id and the expected _type, so a wrong endpoint fails loudly instead of
+// leaving every field zero-valued.
+func (c *openProjectClient) decode(path string, body []byte, out any) error {
+	if err := json.Unmarshal(body, out); err != nil {
+		return err
+	}
+	resource, ok := out.(halResource)
+	if !c.strict || !ok {
+		return nil
+	}
+	var shape struct {
+		Type string          `json:"_type"`
+		ID   json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(body, &shape); err != nil {
+		return err
+	}
+	if shape.Type != resource.halType() || len(shape.ID) == 0 {
+		return fmt.Errorf("openproject: %s did not return a %s (got _type %q); check the base URL", path, resource.halType(), shape.Type)
+	}
+	return nil
+}
+
+// hrefPath converts an href returned by the API (which already carries any base
+// path of the instance) into a path relative to c.baseURL
+func (c *openProjectClient) hrefPath(href string) string {
+	if u, err := url.Parse(c.baseURL); err == nil && u.Path != "" {
+		return strings.TrimPrefix(href, u.Path)
+	}
+	return href
+}
+
+// do sends a request with an optional JSON body and decodes the JSON response into out (if non-nil).
+// Transient failures are retried with exponential backoff until c.maxRetries or the deadline is hit.
+func (c *openProjectClient) do(ctx context.Context, method, path string, payload any, out any) error {
+	var data []byte
+	contentType := ""
+	if payload != nil {
+		var err error
+		data, err = json.Marshal(payload)
+		if err != nil {
+			return err
+		}
+		contentType = "application/json"
+	}
+	return c.doBody(ctx, method, path, data, contentType, out)
+}
+
+// doBody is do with an already encoded body, e.g. a multipart upload
+func (c *openProjectClient) doBody(ctx context.Context, method, path string, data []byte, contentType string, out any) error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	if c.dryRun && method != "GET" {
+		if contentType != "application/json" {
+			log.Printf("openproject-mcp: %sdry run, not sending %s %s (%d bytes of %s)", logPrefix(ctx), method, c.baseURL+path, len(data), contentType)
+			return &dryRunRequest{Method: method, URL: c.baseURL + path}
+		}
+		log.Printf("openproject-mcp: %sdry run, not sending %s %s %s", logPrefix(ctx), method, c.baseURL+path, data)
+		return &dryRunRequest{Method: method, URL: c.baseURL + path, Body: data}
+	}
+
+	resp, body, err := c.roundTrip(ctx, method, path, data, contentType, nil)
+	if err != nil {
+		return err
+	}
+	if method != "GET" && c.cache != nil && !isFormPath(path) {
+		c.cache.clear()
+	}
+	return c.readBody(method, path, resp, body, out)
+}
+
+// isFormPath reports whether path is an API v3 form, e.g. /api/v3/time_entries/form.
+// Posting to a form only validates the payload and changes nothing.
+func isFormPath(path string) bool {
+	path, _, _ = strings.Cut(path, "?")
+	return strings.HasSuffix(path, "/form")
+}
+
+// roundTrip sends a request, retrying transient failures with exponential backoff
+// until c.maxRetries or the deadline is hit, and turns error statuses into an
+// *APIError. header holds extra request headers; a 304 answer to a conditional
+// header is returned as is.
+func (c *openProjectClient) roundTrip(ctx context.Context, method, path string, data []byte, contentType string, header http.Header) (*http.Response, []byte, error) {
+	var resp *http.Response
+	var body []byte
+	var err error
+	for attempt := 0; ; attempt++ {
+		resp, body, err = c.send(ctx, method, path, data, contentType, header)
+		if attempt >= c.maxRetries || !shouldRetry(method, resp, err) {
+			break
+		}
+		wait := retryDelay(attempt, resp)
+		c.logf(ctx, "%s %s: retrying in %s (attempt %d of %d)", method, path, wait, attempt+1, c.maxRetries)
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			if err == nil {
+				err = checkResponse(resp, body)
+			}
+			return nil, nil, fmt.Errorf("%w (gave up retrying: %v)", err, ctx.Err())
+		case <-timer.C:
+		}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if resp.StatusCode == http.StatusNotModified && header.Get("If-None-Match") != "" {
+		return resp, body, nil
+	}
+	if err := checkResponse(resp, body); err != nil {
+		return nil, nil, err
+	}
+	return resp, body, nil
+}
+
+// readBody decodes a successful JSON response into out (if non-nil)
+func (c *openProjectClient) readBody(method, path string, resp *http.Response, body []byte, out any) error {
+	if out == nil {
+		return nil
+	}
+	if len(bytes.TrimSpace(body)) == 0 {
+		// e.g. 204 No Content after a DELETE
+		if method != "GET" {
+			return nil
+		}
+		return fmt.Errorf("openproject: %s returned an empty body", path)
+	}
+	if !isJSONResponse(resp) {
+		return fmt.Errorf("openproject: %s returned %s instead of JSON: %s", path, resp.Header.Get("Content-Type"), bodySnippet(body))
+	}
+	return c.decode(path, body, out)
+}
+
+// isJSONResponse reports whether the response declares a JSON body
+// (application/json, application/hal+json, ...); a missing Content-Type is accepted
+func isJSONResponse(resp *http.Response) bool {
+	contentType := resp.Header.Get("Content-Type")
+	if contentType == "" {
+		return true
+	}
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	return err == nil && (mediaType == "application/json" || strings.HasSuffix(mediaType, "+json"))
+}
+
+// bodySnippet is the start of body on a single line, for error messages
+func bodySnippet(body []byte) string {
+	return strconv.Quote(excerpt(string(body), 200))
+}
+
+// excerpt collapses whitespace, including newlines, and cuts text to at most maxLen runes
+func excerpt(text string, maxLen int) string {
+	text = strings.Join(strings.Fields(text), " ")
+	if runes := []rune(text); len(runes) > maxLen {
+		text = strings.TrimRight(string(runes[:maxLen]), " ") + "…"
+	}
+	return text
+}
+
+// send performs a single HTTP round trip and reads the whole response body
+func (c *openProjectClient) send(ctx context.Context, method, path string, data []byte, contentType string, header http.Header) (*http.Response, []byte, error) {
+	var reqBody io.Reader
+	if data != nil {
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.baseURL+path, reqBody)
+	if err != nil {
+		return nil, nil, err
+	}
+	c.setHeaders(req, "application/hal+json", contentType)
+	for name, values := range header {
+		req.Header[name] = values
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, nil, err
+	}
+	start := time.Now()
+	resp, err := c.httpClient.Do(req)
+	elapsed := time.Since(start)
+	if err != nil {
+		c.metrics.requestDone(0, elapsed)
+		c.logRequest(ctx, method, req.URL, 0, elapsed, err)
+		return nil, nil, fmt.Errorf("%w: %w", ErrNetwork, err)
+	}
+	c.metrics.requestDone(resp.StatusCode, elapsed)
+	c.logRequest(ctx, method, req.URL, resp.StatusCode, elapsed, nil)
+	defer resp.Body.Close()
+	body, err := io.ReadAll(io.LimitReader(resp.Body, c.maxResponseBytes+1))
+	if err != nil {
+		return nil, nil, err
+	}
+	if int64(len(body)) > c.maxResponseBytes {
+		return nil, nil, fmt.Errorf("%s %s: %w (%d bytes)", method, path, errResponseTooLarge, c.maxResponseBytes)
+	}
+	return resp, body, nil
+}
+
+// setHeaders applies the headers every OpenProject request carries: Accept,
+// the credentials and, only for requests with a body, Content-Type
+func (c *openProjectClient) setHeaders(req *http.Request, accept, contentType string) {
+	req.Header.Set("Accept", accept)
+	// empty in OAuth2 mode, where the transport adds a bearer token
+	if c.basicAuth != "" {
+		req.Header.Set("Authorization", c.basicAuth)
+	}
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+}
+
+// errResponseTooLarge is not retried: the same request would return the same body
+var errResponseTooLarge = errors.New("response is larger than --max-response-bytes")
+
+// shouldRetry reports whether a failed attempt is worth repeating. Connection errors
+// are not retried for POST, since the server may already have created the resource.
+func shouldRetry(method string, resp *http.Response, err error) bool {
+	if err != nil {
+		return method != "POST" && !errors.Is(err, context.Canceled) && !errors.Is(err, context.DeadlineExceeded) && !errors.Is(err, errResponseTooLarge)
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryDelay honors Retry-After on 429 responses and otherwise backs off
+// exponentially from 500ms with up to 50% jitter
+func retryDelay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil && resp.StatusCode == http.StatusTooManyRequests {
+		if retryAfter := resp.Header.Get("Retry-After"); retryAfter != "" {
+			if seconds, err := strconv.Atoi(retryAfter); err == nil && seconds >= 0 {
+				return time.Duration(seconds) * time.Second
+			}
+			if at, err := http.ParseTime(retryAfter); err == nil {
+				return max(time.Until(at), 0)
+			}
+		}
+	}
+	delay := 500 * time.Millisecond << attempt
+	return delay + time.Duration(rand.Int63n(int64(delay)/2+1))
+}
+
+// Failure categories of OpenProject requests, for use with errors.Is.
+// *APIError matches the first four by status code; transport failures wrap ErrNetwork.
+var (
+	ErrUnauthorized = errors.New("openproject: unauthorized")
+	ErrForbidden    = errors.New("openproject: forbidden")
+	ErrNotFound     = errors.New("openproject: not found")
+	ErrRateLimited  = errors.New("openproject: rate limited")
+	ErrNetwork      = errors.New("openproject: request failed")
+)
+
+// APIError is returned for non-2xx OpenProject responses
+type APIError struct {
+	StatusCode int
+	Path       string
+	Message    string
+	// e.g. urn:openproject-org:api:v3:errors:NotFound
+	ErrorIdentifier string
+	// attributes rejected by a validation failure (422), e.g. assignee
+	Attributes []string
+}
+
+func (e *APIError) Error() string {
+	if e.StatusCode == http.StatusUnprocessableEntity {
+		return fmt.Sprintf("openproject: %s (HTTP %d): validation failed: %s", e.Path, e.StatusCode, e.Message)
+	}
+	return fmt.Sprintf("openproject: %s (HTTP %d): %s", e.Path, e.StatusCode, e.Message)
+}
+
+// Is makes errors.Is(err, ErrNotFound) and friends work on an *APIError
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrUnauthorized:
+		return e.StatusCode == http.StatusUnauthorized
+	case ErrForbidden:
+		return e.StatusCode == http.StatusForbidden
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// dryRunRequest is returned by do for a mutating request that --dry-run kept
+// from being sent; bind reports it to the caller as a successful result
+type dryRunRequest struct {
+	Method string          `json:"method"`
+	URL    string          `json:"url"`
+	Body   json.RawMessage `json:"body,omitempty"`
+}
+
+func (r *dryRunRequest) Error() string {
+	return fmt.Sprintf("dry run: %s %s was not sent", r.Method, r.URL)
+}
+
+// isStatus reports whether err is an APIError with the given HTTP status code
+func isStatus(err error, statusCode int) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == statusCode
+}
+
+// isValidationError reports whether err is a validation failure (422) that rejected attribute
+func isValidationError(err error, attribute string) bool {
+	var apiErr *APIError
+	return errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusUnprocessableEntity && slices.Contains(apiErr.Attributes, attribute)
+}
+
+// checkResponse turns a non-2xx OpenProject response into an *APIError
+func checkResponse(resp *http.Response, body []byte) error {
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+	apiErr := &APIError{
+		StatusCode: resp.StatusCode,
+		Path:       resp.Request.URL.Path,
+		Message:    http.StatusText(resp.StatusCode),
+	}
+	if !isJSONResponse(resp) && len(bytes.TrimSpace(body)) > 0 {
+		// e.g. the HTML error page of a reverse proxy
+		apiErr.Message = fmt.Sprintf("%s (%s: %s)", apiErr.Message, resp.Header.Get("Content-Type"), bodySnippet(body))
+		return apiErr
+	}
+	var errResp APIErrorResponse
+	if err := json.Unmarshal(body, &errResp); err == nil && errResp.Type == "Error" && errResp.Message != "" {
+		apiErr.Message = errResp.Message
+		apiErr.ErrorIdentifier = errResp.ErrorIdentifier
+		if resp.StatusCode == http.StatusUnprocessableEntity {
+			apiErr.Message = errResp.validationMessage()
+			apiErr.Attributes = errResp.attributes()
+		}
+	}
+	return apiErr
+}
+
+// jsonResponse wraps v as the JSON text content of a tool response
+func jsonResponse(v any) (*mcp_golang.ToolResponse, error) {
+	result, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(string(result))), nil
+}
+
+// indentResponse re-indents the JSON text contents of a response with two
+// spaces; other text, such as markdown output, is left as it is
+func indentResponse(response *mcp_golang.ToolResponse) {
+	if response == nil {
+		return
+	}
+	for _, content := range response.Content {
+		if content.TextContent == nil {
+			continue
+		}
+		var indented bytes.Buffer
+		if json.Indent(&indented, []byte(content.TextContent.Text), "", "  ") == nil {
+			content.TextContent.Text = indented.String()
+		}
+	}
+}
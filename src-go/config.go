@@ -0,0 +1,70 @@
+package main
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config is the file passed with --config. Explicit flags override its values,
+// which in turn override environment variables.
+type Config struct {
+	APIKey    string `json:"apiKey" yaml:"apiKey"`
+	BaseURL   string `json:"baseUrl" yaml:"baseUrl"`
+	Timeout   int    `json:"timeout" yaml:"timeout"`
+	Transport string `json:"transport" yaml:"transport"`
+	// additional named instances, selected per call with the instance argument
+	Instances map[string]InstanceConfig `json:"instances" yaml:"instances"`
+}
+
+type InstanceConfig struct {
+	BaseURL string `json:"baseUrl" yaml:"baseUrl"`
+	APIKey  string `json:"apiKey" yaml:"apiKey"`
+}
+
+// loadConfigFile reads a JSON or YAML (.yaml/.yml) config file and validates it
+func loadConfigFile(path string) (*Config, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var cfg Config
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		decoder := yaml.NewDecoder(bytes.NewReader(data))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&cfg); err != nil {
+			return nil, fmt.Errorf("config %s: %w", path, err)
+		}
+	default:
+		decoder := json.NewDecoder(bytes.NewReader(data))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(&cfg); err != nil {
+			var typeErr *json.UnmarshalTypeError
+			if errors.As(err, &typeErr) {
+				return nil, fmt.Errorf("config %s: field %q: expected %s, got %s", path, typeErr.Field, typeErr.Type, typeErr.Value)
+			}
+			return nil, fmt.Errorf("config %s: %w", path, err)
+		}
+	}
+
+	if cfg.Timeout < 0 {
+		return nil, fmt.Errorf("config %s: field %q: must be a positive number of seconds", path, "timeout")
+	}
+	if cfg.Transport != "" && cfg.Transport != "stdio" && cfg.Transport != "sse" {
+		return nil, fmt.Errorf("config %s: field %q: must be stdio or sse, got %q", path, "transport", cfg.Transport)
+	}
+	for name, instance := range cfg.Instances {
+		if instance.BaseURL == "" || instance.APIKey == "" {
+			return nil, fmt.Errorf("config %s: instance %q: baseUrl and apiKey are required", path, name)
+		}
+	}
+	return &cfg, nil
+}
@@ -0,0 +1,126 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+var customFieldName = regexp.MustCompile(`^customField[0-9]+$`)
+
+type SetCustomFieldArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	FieldName     string `json:"field_name" jsonschema:"required,description=Custom field property as shown in customFields of get_detail_work_package_by_id,example=customField3"`
+	Value         any    `json:"value" jsonschema:"required,description=New value: text\\, number or true/false for plain fields; the ID (or list of IDs) of the user\\, version or list option for linked fields; null to clear"`
+}
+
+func (a SetCustomFieldArguments) validate() error {
+	if !customFieldName.MatchString(a.FieldName) {
+		return fmt.Errorf("field_name must look like customField3, got %q", a.FieldName)
+	}
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+// customFieldResources maps the schema type of linked custom fields to their API collection
+var customFieldResources = map[string]string{
+	"User":         "users",
+	"Group":        "groups",
+	"Version":      "versions",
+	"CustomOption": "custom_options",
+}
+
+// setCustomField looks the field up in the work package's schema to decide
+// whether it is a property or a link, then patches it
+func (c *openProjectClient) setCustomField(ctx context.Context, arguments SetCustomFieldArguments) (*mcp_golang.ToolResponse, error) {
+	var current WorkPackageResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID), &current); err != nil {
+		return nil, err
+	}
+	var schema map[string]json.RawMessage
+	if err := c.getCached(ctx, c.hrefPath(current.Links.Schema.Href), &schema); err != nil {
+		return nil, err
+	}
+	var property SchemaPropertyResponse
+	if err := json.Unmarshal(schema[arguments.FieldName], &property); err != nil {
+		return nil, fmt.Errorf("work package %d has no custom field %s", arguments.WorkPackageID, arguments.FieldName)
+	}
+
+	payload := WorkPackagePayload{LockVersion: arguments.LockVersion}
+	// "[]CustomOption" for multi-select fields
+	fieldType, multiple := strings.CutPrefix(property.Type, "[]")
+	if resource, linked := customFieldResources[fieldType]; linked {
+		link, err := customFieldLink(resource, arguments.Value, multiple)
+		if err != nil {
+			return nil, fmt.Errorf("%s (%s): %w", arguments.FieldName, property.Name, err)
+		}
+		payload.CustomFieldLinks = map[string]any{arguments.FieldName: link}
+	} else {
+		value := arguments.Value
+		if fieldType == "Formattable" && value != nil {
+			value = Formattable{Raw: fmt.Sprint(value)}
+		}
+		payload.CustomFields = map[string]any{arguments.FieldName: value}
+	}
+
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, payload)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID    int             `json:"id"`
+		Field string          `json:"field"`
+		Value json.RawMessage `json:"value"`
+	}{updated.ID, arguments.FieldName, updated.CustomFields[arguments.FieldName]})
+}
+
+// customFieldLink turns an ID (or list of IDs for multi-valued fields) into the
+// link value; nil clears the field
+func customFieldLink(resource string, value any, multiple bool) (any, error) {
+	values, isList := value.([]any)
+	if !isList {
+		values = []any{value}
+		if value == nil {
+			values = nil
+		}
+	}
+	if isList && !multiple {
+		return nil, fmt.Errorf("only takes a single ID")
+	}
+	links := make([]Link, 0, len(values))
+	for _, v := range values {
+		id, err := resourceID(v)
+		if err != nil {
+			return nil, err
+		}
+		links = append(links, Link{Href: fmt.Sprintf("/api/v3/%s/%d", resource, id)})
+	}
+	if multiple {
+		return links, nil
+	}
+	if len(links) == 0 {
+		return Link{}, nil
+	}
+	return links[0], nil
+}
+
+// resourceID accepts a JSON number or a numeric string
+func resourceID(v any) (int, error) {
+	switch v := v.(type) {
+	case float64:
+		if v == float64(int(v)) && v > 0 {
+			return int(v), nil
+		}
+	case string:
+		if id, err := strconv.Atoi(v); err == nil && id > 0 {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("expected a positive integer ID, got %v", v)
+}
@@ -0,0 +1,60 @@
+package main
+
+import (
+	"fmt"
+	"time"
+)
+
+// dateFormat converts the ISO 8601 dates and timestamps OpenProject returns
+// into a caller's time zone and layout. The zero value passes them through.
+type dateFormat struct {
+	location *time.Location
+	layout   string
+}
+
+// newDateFormat validates timezone against the IANA database; an empty
+// timezone keeps the zone the API returned
+func newDateFormat(timezone, layout string) (dateFormat, error) {
+	f := dateFormat{layout: layout}
+	if timezone != "" {
+		location, err := time.LoadLocation(timezone)
+		if err != nil {
+			return f, fmt.Errorf("unknown timezone %q: %w", timezone, err)
+		}
+		f.location = location
+	}
+	return f, nil
+}
+
+// timestamp formats an RFC 3339 timestamp such as createdAt, defaulting to
+// RFC 3339 in the target zone when only a timezone was given
+func (f dateFormat) timestamp(s string) string {
+	if s == "" || (f.location == nil && f.layout == "") {
+		return s
+	}
+	t, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		return s
+	}
+	if f.location != nil {
+		t = t.In(f.location)
+	}
+	layout := f.layout
+	if layout == "" {
+		layout = time.RFC3339
+	}
+	return t.Format(layout)
+}
+
+// date formats a calendar date such as startDate; dates have no time of day,
+// so only the layout applies
+func (f dateFormat) date(s string) string {
+	if s == "" || f.layout == "" {
+		return s
+	}
+	t, err := time.Parse(time.DateOnly, s)
+	if err != nil {
+		return s
+	}
+	return t.Format(f.layout)
+}
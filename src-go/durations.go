@@ -0,0 +1,62 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// durationMinutes rounds decimal hours to the nearest minute, the precision formatISODuration sends
+func durationMinutes(hours float64) int {
+	return int(math.Round(hours * 60))
+}
+
+// formatISODuration renders decimal hours as the ISO 8601 duration OpenProject
+// expects (e.g. 2.5 -> "PT2H30M"), rounded to the nearest minute
+func formatISODuration(hours float64) string {
+	minutes := durationMinutes(hours)
+	h, m := minutes/60, minutes%60
+	var b strings.Builder
+	b.WriteString("PT")
+	if h > 0 {
+		fmt.Fprintf(&b, "%dH", h)
+	}
+	if m > 0 || h == 0 {
+		fmt.Fprintf(&b, "%dM", m)
+	}
+	return b.String()
+}
+
+// parseISODuration converts an ISO 8601 duration as returned by OpenProject
+// (e.g. "PT2H30M", "P1DT1H") into decimal hours; a day counts as 24 hours
+func parseISODuration(s string) (float64, error) {
+	rest, ok := strings.CutPrefix(s, "P")
+	if !ok || rest == "" {
+		return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+	}
+	units := map[byte]float64{'W': 7 * 24, 'D': 24}
+	var hours float64
+	for rest != "" {
+		if rest[0] == 'T' {
+			units = map[byte]float64{'H': 1, 'M': 1.0 / 60, 'S': 1.0 / 3600}
+			rest = rest[1:]
+			continue
+		}
+		i := strings.IndexFunc(rest, func(r rune) bool { return (r < '0' || r > '9') && r != '.' })
+		if i <= 0 {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+		}
+		value, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, fmt.Errorf("invalid ISO 8601 duration %q", s)
+		}
+		factor, ok := units[rest[i]]
+		if !ok {
+			return 0, fmt.Errorf("unsupported unit %q in ISO 8601 duration %q", rest[i], s)
+		}
+		hours += value * factor
+		rest = rest[i+1:]
+	}
+	return hours, nil
+}
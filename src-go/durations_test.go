@@ -0,0 +1,77 @@
+package main
+
+import (
+	"math"
+	"testing"
+)
+
+func TestParseISODuration(t *testing.T) {
+	tests := []struct {
+		in      string
+		want    float64
+		wantErr bool
+	}{
+		{in: "PT2H30M", want: 2.5},
+		{in: "P1DT2H", want: 26},
+		{in: "P1W", want: 168},
+		{in: "PT0.5H", want: 0.5},
+		{in: "PT45S", want: 0.0125},
+		{in: "PT0S", want: 0},
+		{in: "", wantErr: true},
+		{in: "P", wantErr: true},
+		{in: "2H30M", wantErr: true},
+		{in: "PTH", wantErr: true},
+		{in: "PT2X", wantErr: true},
+		{in: "PT1.2.3H", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			got, err := parseISODuration(tt.in)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("parseISODuration(%q) = %v, want an error", tt.in, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseISODuration(%q) failed: %v", tt.in, err)
+			}
+			if math.Abs(got-tt.want) > 1e-9 {
+				t.Errorf("parseISODuration(%q) = %v, want %v", tt.in, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFormatISODuration(t *testing.T) {
+	tests := []struct {
+		hours float64
+		want  string
+	}{
+		{hours: 2.5, want: "PT2H30M"},
+		{hours: 26, want: "PT26H"},
+		{hours: 0.5, want: "PT30M"},
+		{hours: 1.0 / 60, want: "PT1M"},
+		// rounded to the nearest minute
+		{hours: 0.0125, want: "PT1M"},
+		{hours: 1.999, want: "PT2H"},
+		{hours: 0, want: "PT0M"},
+	}
+	for _, tt := range tests {
+		if got := formatISODuration(tt.hours); got != tt.want {
+			t.Errorf("formatISODuration(%v) = %q, want %q", tt.hours, got, tt.want)
+		}
+	}
+}
+
+func TestISODurationRoundTrip(t *testing.T) {
+	for _, in := range []string{"PT2H30M", "PT45M", "PT8H", "PT100H5M"} {
+		hours, err := parseISODuration(in)
+		if err != nil {
+			t.Fatalf("parseISODuration(%q) failed: %v", in, err)
+		}
+		if got := formatISODuration(hours); got != in {
+			t.Errorf("formatISODuration(parseISODuration(%q)) = %q", in, got)
+		}
+	}
+}
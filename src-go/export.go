@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// deepest tree export_work_package_tree will walk, whatever max_depth asks for
+const maxExportDepth = 10
+
+type ExportWorkPackageTreeArguments struct {
+	ConnectionArguments
+	WorkPackageID int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of the root of the exported tree,example=42"`
+	MaxDepth      int    `json:"max_depth,omitempty" jsonschema:"description=Levels of descendants to include below the root (default 3),minimum=1,maximum=10"`
+	Format        string `json:"format,omitempty" jsonschema:"enum=nested,enum=outline,description=nested JSON or an indented text outline (default nested)"`
+}
+
+func (a ExportWorkPackageTreeArguments) validate() error {
+	if a.MaxDepth < 0 || a.MaxDepth > maxExportDepth {
+		return fmt.Errorf("max_depth must be between 1 and %d", maxExportDepth)
+	}
+	if a.Format != "" && a.Format != "nested" && a.Format != "outline" {
+		return fmt.Errorf("unknown format %q, expected nested or outline", a.Format)
+	}
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+type WorkPackageNode struct {
+	ID       int                `json:"id"`
+	Subject  string             `json:"subject"`
+	Status   string             `json:"status"`
+	Assignee string             `json:"assignee,omitempty"`
+	Children []*WorkPackageNode `json:"children,omitempty"`
+	// set when the work package has children below max_depth that were not fetched
+	Truncated bool `json:"truncated,omitempty"`
+
+	childIDs []int
+}
+
+// exportWorkPackageTree walks the tree breadth first, fetching each level's
+// work packages concurrently
+func (c *openProjectClient) exportWorkPackageTree(ctx context.Context, arguments ExportWorkPackageTreeArguments) (*mcp_golang.ToolResponse, error) {
+	maxDepth := arguments.MaxDepth
+	if maxDepth == 0 {
+		maxDepth = 3
+	}
+	roots, err := c.fetchWorkPackageNodes(ctx, []int{arguments.WorkPackageID})
+	if err != nil {
+		return nil, err
+	}
+	root := roots[0]
+
+	// children can only have one parent, but guard against a broken hierarchy anyway
+	seen := map[int]bool{root.ID: true}
+	level := []*WorkPackageNode{root}
+	for depth := 0; depth < maxDepth && len(level) > 0; depth++ {
+		var ids []int
+		var parents []*WorkPackageNode
+		for _, node := range level {
+			for _, id := range node.childIDs {
+				if !seen[id] {
+					seen[id] = true
+					ids = append(ids, id)
+					parents = append(parents, node)
+				}
+			}
+		}
+		next, err := c.fetchWorkPackageNodes(ctx, ids)
+		if err != nil {
+			return nil, err
+		}
+		for i, node := range next {
+			parents[i].Children = append(parents[i].Children, node)
+		}
+		level = next
+	}
+	for _, node := range level {
+		node.Truncated = len(node.childIDs) > 0
+	}
+
+	if arguments.Format == "outline" {
+		var b strings.Builder
+		writeOutline(&b, root, 0)
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(b.String())), nil
+	}
+	return jsonResponse(root)
+}
+
+// fetchWorkPackageNodes fetches ids with at most c.concurrency requests in flight,
+// returning nodes in the order of ids
+func (c *openProjectClient) fetchWorkPackageNodes(ctx context.Context, ids []int) ([]*WorkPackageNode, error) {
+	nodes := make([]*WorkPackageNode, len(ids))
+	errs := make([]error, len(ids))
+	c.forEach(len(ids), func(i int) {
+		var wpResp WorkPackageResponse
+		if errs[i] = c.getCached(ctx, fmt.Sprintf("/api/v3/work_packages/%d", ids[i]), &wpResp); errs[i] != nil {
+			return
+		}
+		node := &WorkPackageNode{ID: wpResp.ID, Subject: wpResp.Subject, Status: wpResp.Links.Status.Title, Assignee: wpResp.Links.Assignee.Title}
+		for _, link := range wpResp.Links.Children {
+			node.childIDs = append(node.childIDs, link.ID())
+		}
+		nodes[i] = node
+	})
+	for _, err := range errs {
+		if err != nil {
+			return nil, err
+		}
+	}
+	return nodes, nil
+}
+
+func writeOutline(b *strings.Builder, node *WorkPackageNode, indent int) {
+	fmt.Fprintf(b, "%s- #%d %s [%s]", strings.Repeat("  ", indent), node.ID, node.Subject, node.Status)
+	if node.Assignee != "" {
+		fmt.Fprintf(b, " (%s)", node.Assignee)
+	}
+	if node.Truncated {
+		b.WriteString(" …")
+	}
+	b.WriteString("\n")
+	for _, child := range node.Children {
+		writeOutline(b, child, indent+1)
+	}
+}
@@ -0,0 +1,56 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Filter is one entry of OpenProject's `filters` query parameter,
+// e.g. {"status": {"operator": "o", "values": []}}
+type Filter map[string]FilterCondition
+
+type FilterCondition struct {
+	Operator string   `json:"operator"`
+	Values   []string `json:"values"`
+}
+
+func newFilter(name, operator string, values ...string) Filter {
+	if values == nil {
+		values = []string{}
+	}
+	return Filter{name: {Operator: operator, Values: values}}
+}
+
+// encodeFilters renders filters as the URL-encoded JSON array OpenProject expects
+func encodeFilters(filters []Filter) (string, error) {
+	data, err := json.Marshal(filters)
+	if err != nil {
+		return "", err
+	}
+	return url.QueryEscape(string(data)), nil
+}
+
+// encodeSortBy translates "field" / "-field" (comma separated for several keys,
+// e.g. "priority,-updatedAt") into OpenProject's URL-encoded sortBy JSON,
+// e.g. [["priority","asc"],["updatedAt","desc"]]
+func encodeSortBy(spec string) (string, error) {
+	var sortBy [][2]string
+	for _, field := range strings.Split(spec, ",") {
+		field = strings.TrimSpace(field)
+		direction := "asc"
+		if rest, ok := strings.CutPrefix(field, "-"); ok {
+			field, direction = rest, "desc"
+		}
+		if field == "" {
+			return "", fmt.Errorf("invalid sort_by %q, expected e.g. \"id\" or \"-updatedAt\"", spec)
+		}
+		sortBy = append(sortBy, [2]string{field, direction})
+	}
+	data, err := json.Marshal(sortBy)
+	if err != nil {
+		return "", err
+	}
+	return url.QueryEscape(string(data)), nil
+}
@@ -2,7 +2,12 @@ module open-project-mcp-server
 
 go 1.21.0
 
-require github.com/metoro-io/mcp-golang v0.11.0
+require (
+	github.com/metoro-io/mcp-golang v0.11.0
+	golang.org/x/oauth2 v0.26.0
+	golang.org/x/time v0.10.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/bahlo/generic-list-go v0.2.0 // indirect
@@ -15,5 +20,4 @@ require (
 	github.com/tidwall/pretty v1.2.1 // indirect
 	github.com/tidwall/sjson v1.2.5 // indirect
 	github.com/wk8/go-ordered-map/v2 v2.1.8 // indirect
-	gopkg.in/yaml.v3 v3.0.1 // indirect
 )
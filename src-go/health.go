@@ -0,0 +1,56 @@
+package main
+
+import (
+	"context"
+	"errors"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type CheckConnectionArguments struct {
+	ConnectionArguments
+}
+
+type RootResponse struct {
+	InstanceName string `json:"instanceName"`
+	// only returned to admins
+	CoreVersion string `json:"coreVersion"`
+}
+
+type ConnectionStatus struct {
+	BaseURL       string `json:"baseUrl"`
+	Reachable     bool   `json:"reachable"`
+	Authenticated bool   `json:"authenticated"`
+	InstanceName  string `json:"instanceName,omitempty"`
+	Version       string `json:"version,omitempty"`
+	User          string `json:"user,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// checkConnection verifies the base URL and API key without needing any
+// work package ID. Rejected credentials are reported in the result rather
+// than as a tool error so the caller can see how far the check got.
+func (c *openProjectClient) checkConnection(ctx context.Context, arguments CheckConnectionArguments) (*mcp_golang.ToolResponse, error) {
+	status := ConnectionStatus{BaseURL: c.baseURL}
+
+	var root RootResponse
+	if err := c.get(ctx, "/api/v3", &root); err != nil {
+		// any HTTP response, even an error status, means the server was reached
+		status.Reachable = !errors.Is(err, ErrNetwork)
+		status.Error = err.Error()
+		return jsonResponse(status)
+	}
+	status.Reachable = true
+	status.InstanceName = root.InstanceName
+	status.Version = root.CoreVersion
+
+	// the API root can be public, only /users/me proves the key works
+	var me UserResponse
+	if err := c.get(ctx, "/api/v3/users/me", &me); err != nil {
+		status.Error = err.Error()
+		return jsonResponse(status)
+	}
+	status.Authenticated = true
+	status.User = me.Name
+	return jsonResponse(status)
+}
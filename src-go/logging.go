@@ -0,0 +1,82 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"time"
+)
+
+type callInfoKey struct{}
+
+// callInfo identifies the tool invocation a request is made for
+type callInfo struct {
+	tool string
+	// short random ID shared by every log line of one invocation
+	correlationID string
+}
+
+// withCall records the tool being served and a fresh correlation ID
+func withCall(ctx context.Context, tool string) (context.Context, string) {
+	b := make([]byte, 4)
+	_, _ = rand.Read(b)
+	id := hex.EncodeToString(b)
+	return context.WithValue(ctx, callInfoKey{}, callInfo{tool: tool, correlationID: id}), id
+}
+
+func callFrom(ctx context.Context) callInfo {
+	info, _ := ctx.Value(callInfoKey{}).(callInfo)
+	return info
+}
+
+// logPrefix is "[correlation ID] " inside a tool call, empty otherwise
+func logPrefix(ctx context.Context) string {
+	if id := callFrom(ctx).correlationID; id != "" {
+		return "[" + id + "] "
+	}
+	return ""
+}
+
+// callAttrs are the tool and correlation_id fields of structured logs
+func callAttrs(ctx context.Context) []slog.Attr {
+	info := callFrom(ctx)
+	if info.tool == "" {
+		return nil
+	}
+	return []slog.Attr{slog.String("tool", info.tool), slog.String("correlation_id", info.correlationID)}
+}
+
+// logf writes a verbose log line tagged with the current tool call
+func (c *openProjectClient) logf(ctx context.Context, format string, args ...any) {
+	if c.requestLogger != nil {
+		c.requestLogger.LogAttrs(ctx, slog.LevelInfo, fmt.Sprintf(format, args...), callAttrs(ctx)...)
+		return
+	}
+	c.logger.Printf(logPrefix(ctx)+format, args...)
+}
+
+// logRequest logs one HTTP round trip, as a JSON object with --log-format=json
+// and as a line of text otherwise. status is 0 when no response was received.
+func (c *openProjectClient) logRequest(ctx context.Context, method string, u *url.URL, status int, elapsed time.Duration, err error) {
+	if c.requestLogger == nil {
+		if err != nil {
+			c.logf(ctx, "%s %s failed after %s: %v", method, u, elapsed, err)
+		} else {
+			c.logf(ctx, "%s %s -> %d in %s", method, u, status, elapsed)
+		}
+		return
+	}
+	attrs := append(callAttrs(ctx),
+		slog.String("method", method),
+		slog.String("url", u.String()),
+		slog.Int("status", status),
+		slog.Int64("duration_ms", elapsed.Milliseconds()),
+	)
+	if err != nil {
+		attrs = append(attrs, slog.String("error", err.Error()))
+	}
+	c.requestLogger.LogAttrs(ctx, slog.LevelInfo, "request", attrs...)
+}
@@ -1,150 +1,355 @@
 package main
 
 import (
-	"encoding/base64"
-	"encoding/json"
+	"context"
 	"flag"
-	"fmt"
 	"io"
-	"net/http"
+	"log"
+	"log/slog"
+	"net"
 	"os"
+	"os/signal"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	mcp_golang "github.com/metoro-io/mcp-golang"
+	"github.com/metoro-io/mcp-golang/transport"
 	"github.com/metoro-io/mcp-golang/transport/stdio"
+	"golang.org/x/time/rate"
 )
 
-// Add arguments struct for the curl tool
-// No arguments needed for this specific endpoint, but struct is required by framework
-// You can extend this to accept URL, headers, etc. if needed
-type CurlToolArguments struct {
-	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject"`
-}
-
-type WorkPackageResponse struct {
-	ID          int    `json:"id"`
-	Subject     string `json:"subject"`
-	Description struct {
-		Raw string `json:"raw"`
-	} `json:"description"`
-	Links struct {
-		Status struct {
-			Title string `json:"title"`
-		} `json:"status"`
-		Assignee struct {
-			Title string `json:"title"`
-		} `json:"assignee"`
-	} `json:"_links"`
-	Embedded struct {
-		Attachments struct {
-			Embedded struct {
-				Elements []struct {
-					Links struct {
-						DownloadLocation struct {
-							Href string `json:"href"`
-						} `json:"downloadLocation"`
-					} `json:"_links"`
-				} `json:"elements"`
-			} `json:"_embedded"`
-		} `json:"attachments"`
-		Activities struct {
-			Embedded struct {
-				Elements []struct {
-					Comment struct {
-						Raw string `json:"raw"`
-					} `json:"comment"`
-				} `json:"elements"`
-			} `json:"_embedded"`
-		} `json:"activities"`
-	} `json:"_embedded"`
-}
-
 func main() {
 	done := make(chan struct{})
 
 	apiKey := ""
+	baseURL := ""
+	timeoutSeconds := 0
+	verbose := false
+	transportName := ""
+	host := ""
+	port := 0
+	sseToken := ""
+	maxPages := 0
+	maxRetries := 0
+	cacheTTLSeconds := 0
+	configPath := ""
+	var maxAttachmentSize int64
+	var maxResponseBytes int64
+	dryRun := false
+	strict := false
+	selectFields := false
+	proxy := ""
+	caCertPath := ""
+	insecure := false
+	concurrency := 0
+	rateLimit := 0.0
+	authMode := ""
+	logFormat := ""
+	enableTools := ""
+	disableTools := ""
+	var oauth oauth2Options
+	instances := map[string]instance{}
 	flag.StringVar(&apiKey, "apikey", "", "OpenProject API key")
+	flag.StringVar(&baseURL, "baseurl", "", "OpenProject base URL, e.g. https://openproject.example.com")
+	flag.IntVar(&timeoutSeconds, "timeout", 30, "HTTP request timeout in seconds")
+	flag.BoolVar(&verbose, "verbose", false, "Log each OpenProject request to stderr")
+	flag.StringVar(&logFormat, "log-format", "text", "Format of the --verbose logs: text or json (one object per line)")
+	flag.StringVar(&transportName, "transport", "stdio", "MCP transport: stdio or sse")
+	flag.StringVar(&host, "host", "127.0.0.1", "Address to listen on when --transport=sse; anything but a loopback address requires --sse-token")
+	flag.IntVar(&port, "port", 8080, "Port to listen on when --transport=sse")
+	flag.StringVar(&sseToken, "sse-token", "", "Bearer token SSE clients must send (default: OPENPROJECT_MCP_SSE_TOKEN env var)")
+	flag.IntVar(&maxPages, "max-pages", 50, "Maximum number of pages followed when listing a collection; listing a larger one fails")
+	flag.IntVar(&maxRetries, "max-retries", 3, "Maximum retries for transient OpenProject failures")
+	flag.IntVar(&cacheTTLSeconds, "cache-ttl", 0, "Cache work package responses for this many seconds (0 disables caching)")
+	flag.StringVar(&configPath, "config", "", "Path to a JSON or YAML config file")
+	flag.Int64Var(&maxAttachmentSize, "max-attachment-size", 10*1024*1024, "Maximum attachment size in bytes that download_attachment returns")
+	flag.BoolVar(&dryRun, "dry-run", false, "Log create/update/delete requests to stderr instead of sending them")
+	flag.BoolVar(&strict, "strict", false, "Fail when a response does not look like the requested resource (e.g. wrong base URL)")
+	flag.BoolVar(&selectFields, "select", true, "Request only the needed properties of work package pages with select; disable for instances that mishandle it")
+	flag.StringVar(&proxy, "proxy", "", "HTTP(S) proxy URL for OpenProject requests (default: HTTPS_PROXY/HTTP_PROXY env vars)")
+	flag.StringVar(&caCertPath, "ca-cert", "", "Path to a PEM bundle of additional CA certificates to trust")
+	flag.BoolVar(&insecure, "insecure", false, "Skip TLS certificate verification (testing only)")
+	flag.IntVar(&concurrency, "concurrency", 4, "Maximum parallel OpenProject requests made by batch tools")
+	flag.Float64Var(&rateLimit, "rate-limit", 0, "Maximum OpenProject requests per second across all tools (0 = unlimited)")
+	flag.StringVar(&authMode, "auth-mode", "apikey", "Authentication: apikey (basic auth with the API key) or oauth2 (client credentials)")
+	flag.StringVar(&oauth.tokenURL, "oauth-token-url", "", "OAuth2 token endpoint, e.g. https://openproject.example.com/oauth/token")
+	flag.StringVar(&oauth.clientID, "oauth-client-id", "", "OAuth2 client ID")
+	flag.StringVar(&oauth.clientSecret, "oauth-client-secret", "", "OAuth2 client secret")
+	flag.StringVar(&enableTools, "enable-tools", "", "Comma-separated write tools to register, or all; tools that change OpenProject data are disabled by default")
+	flag.StringVar(&disableTools, "disable-tools", "", "Comma-separated tools not to register, read or write")
+	flag.Int64Var(&maxResponseBytes, "max-response-bytes", 8*1024*1024, "Maximum size in bytes of an OpenProject API response")
 	flag.Parse()
-	if apiKey == "" {
-		apiKey = os.Getenv("OPENPROJECT_API_KEY")
-	}
-	if apiKey == "" {
-		panic("API key must be provided via --apikey or OPENPROJECT_API_KEY env var")
-	}
-	// b64 encode "apikey:${apiKey}"
-	basicAuth := "Basic " + base64.StdEncoding.EncodeToString([]byte("apikey:"+apiKey))
-
-	server := mcp_golang.NewServer(stdio.NewStdioServerTransport())
 
-	// Register the curl tool
-	err := server.RegisterTool("get_detail_work_package_by_id", "Get work package from OpenProject API by ID", func(arguments CurlToolArguments) (*mcp_golang.ToolResponse, error) {
-		client := &http.Client{}
-		url := fmt.Sprintf("https://project.mywelly.vn/api/v3/work_packages/%d", arguments.WorkPackageID)
-		req, err := http.NewRequest("GET", url, nil)
+	if configPath != "" {
+		cfg, err := loadConfigFile(configPath)
 		if err != nil {
-			return nil, err
+			panic(err)
 		}
-		req.Header.Set("Accept", "application/hal+json")
-		req.Header.Set("Authorization", basicAuth)
-		resp, err := client.Do(req)
-		if err != nil {
-			return nil, err
+		// config file values only fill in what was not given explicitly as a flag
+		explicit := map[string]bool{}
+		flag.Visit(func(f *flag.Flag) { explicit[f.Name] = true })
+		if !explicit["apikey"] && cfg.APIKey != "" {
+			apiKey = cfg.APIKey
 		}
-		defer resp.Body.Close()
-		body, err := io.ReadAll(resp.Body)
-		if err != nil {
-			return nil, err
+		if !explicit["baseurl"] && cfg.BaseURL != "" {
+			baseURL = cfg.BaseURL
 		}
-
-		var wpResp WorkPackageResponse
-		err = json.Unmarshal(body, &wpResp)
-		if err != nil {
-			return nil, err
+		if !explicit["timeout"] && cfg.Timeout != 0 {
+			timeoutSeconds = cfg.Timeout
 		}
-
-		type MinimalWorkPackage struct {
-			ID          int    `json:"id"`
-			Subject     string `json:"subject"`
-			Status      string `json:"status"`
-			Assignee    string `json:"assignee"`
-			Description string `json:"description"`
-			Evidence    string `json:"evidence,omitempty"`
-			Comment     string `json:"comment,omitempty"`
+		if !explicit["transport"] && cfg.Transport != "" {
+			transportName = cfg.Transport
 		}
-
-		wp := MinimalWorkPackage{
-			ID:          wpResp.ID,
-			Subject:     wpResp.Subject,
-			Status:      wpResp.Links.Status.Title,
-			Assignee:    wpResp.Links.Assignee.Title,
-			Description: wpResp.Description.Raw,
+		for name, ic := range cfg.Instances {
+			instances[name] = instance{baseURL: strings.TrimRight(ic.BaseURL, "/"), basicAuth: basicAuthHeader(ic.APIKey)}
 		}
-		// Lấy evidence (ảnh đầu tiên nếu có)
-		if len(wpResp.Embedded.Attachments.Embedded.Elements) > 0 {
-			first := wpResp.Embedded.Attachments.Embedded.Elements[0]
-			wp.Evidence = first.Links.DownloadLocation.Href
+	}
+	if apiKey == "" {
+		apiKey = os.Getenv("OPENPROJECT_API_KEY")
+	}
+	switch authMode {
+	case "apikey":
+		if apiKey == "" {
+			panic("API key must be provided via --apikey, the config file or OPENPROJECT_API_KEY env var")
 		}
-		// Lấy comment đầu tiên nếu có
-		if len(wpResp.Embedded.Activities.Embedded.Elements) > 0 {
-			firstComment := wpResp.Embedded.Activities.Embedded.Elements[0].Comment.Raw
-			if firstComment != "" {
-				wp.Comment = firstComment
-			}
+	case "oauth2":
+		if err := oauth.validate(); err != nil {
+			panic(err)
 		}
+	default:
+		panic("--auth-mode must be apikey or oauth2")
+	}
+	if baseURL == "" {
+		baseURL = os.Getenv("OPENPROJECT_BASE_URL")
+	}
+	if baseURL == "" {
+		panic("Base URL must be provided via --baseurl, the config file or OPENPROJECT_BASE_URL env var")
+	}
+	baseURL = strings.TrimRight(baseURL, "/")
+	if timeoutSeconds <= 0 {
+		panic("--timeout must be a positive number of seconds")
+	}
+	timeout := time.Duration(timeoutSeconds) * time.Second
+	if maxPages <= 0 {
+		panic("--max-pages must be positive")
+	}
+	if maxRetries < 0 {
+		panic("--max-retries must not be negative")
+	}
+	if cacheTTLSeconds < 0 {
+		panic("--cache-ttl must not be negative")
+	}
+	if concurrency <= 0 {
+		panic("--concurrency must be positive")
+	}
+	if rateLimit < 0 {
+		panic("--rate-limit must not be negative")
+	}
+	if maxAttachmentSize <= 0 {
+		panic("--max-attachment-size must be positive")
+	}
+	if maxResponseBytes <= 0 {
+		panic("--max-response-bytes must be positive")
+	}
+	// never log to stdout, it carries the stdio MCP transport
+	logger := log.New(io.Discard, "", 0)
+	var requestLogger *slog.Logger
+	switch logFormat {
+	case "text":
+		if verbose {
+			logger = log.New(os.Stderr, "openproject-mcp: ", log.LstdFlags)
+		}
+	case "json":
+		handler := slog.NewJSONHandler(os.Stderr, nil)
+		// also routes the log.Printf warnings below through the JSON handler
+		slog.SetDefault(slog.New(handler))
+		if verbose {
+			logger = slog.NewLogLogger(handler, slog.LevelInfo)
+			requestLogger = slog.New(handler)
+		}
+	default:
+		panic("--log-format must be text or json")
+	}
 
-		result, err := json.Marshal(wp)
-		if err != nil {
-			return nil, err
+	if insecure {
+		log.Printf("openproject-mcp: WARNING: --insecure disables TLS certificate verification, do not use it in production")
+	}
+	httpClient, err := newHTTPClient(timeout, transportOptions{proxy: proxy, caCertPath: caCertPath, insecure: insecure})
+	if err != nil {
+		panic(err)
+	}
+	basicAuth := ""
+	if authMode == "oauth2" {
+		httpClient = newOAuth2Client(httpClient, oauth)
+	} else {
+		basicAuth = basicAuthHeader(apiKey)
+	}
+
+	client := &openProjectClient{
+		baseURL:           baseURL,
+		basicAuth:         basicAuth,
+		httpClient:        httpClient,
+		timeout:           timeout,
+		maxPages:          maxPages,
+		maxRetries:        maxRetries,
+		logger:            logger,
+		requestLogger:     requestLogger,
+		verbose:           verbose,
+		maxAttachmentSize: maxAttachmentSize,
+		maxResponseBytes:  maxResponseBytes,
+		dryRun:            dryRun,
+		strict:            strict,
+		selectFields:      selectFields,
+		metrics:           newServerMetrics(),
+		concurrency:       concurrency,
+		instances:         instances,
+	}
+	if rateLimit > 0 {
+		// allow a second's worth of requests in a burst, at least one
+		client.limiter = rate.NewLimiter(rate.Limit(rateLimit), max(1, int(rateLimit)))
+	}
+	if cacheTTLSeconds > 0 {
+		client.cache = newResponseCache(time.Duration(cacheTTLSeconds) * time.Second)
+	}
+	var serverTransport transport.Transport
+	switch transportName {
+	case "stdio":
+		serverTransport = stdio.NewStdioServerTransport()
+	case "sse":
+		if sseToken == "" {
+			sseToken = os.Getenv("OPENPROJECT_MCP_SSE_TOKEN")
+		}
+		if ip := net.ParseIP(host); sseToken == "" && host != "localhost" && (ip == nil || !ip.IsLoopback()) {
+			panic("--sse-token or OPENPROJECT_MCP_SSE_TOKEN must be set to listen on " + host + ", every client acts with the server's OpenProject credentials")
 		}
-		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(string(result))), nil
-	})
+		addr := net.JoinHostPort(host, strconv.Itoa(port))
+		sse := newSSETransport(addr, sseToken)
+		sse.Handle("/metrics", client.metrics)
+		serverTransport = sse
+		log.Printf("openproject-mcp: SSE transport listening on %s (stream at /sse)", addr)
+	default:
+		panic("--transport must be stdio or sse")
+	}
+	watcher := newInitializeWatcher(serverTransport)
+	server := mcp_golang.NewServer(watcher)
+
+	tools := []struct {
+		name        string
+		description string
+		handler     boundTool
+	}{
+		// the curl tool
+		{"get_detail_work_package_by_id", "Get work package from OpenProject API by ID", bind(client, (*openProjectClient).getWorkPackage)},
+		{"get_work_package_from_url", "Get an OpenProject work package from a link to it", bind(client, (*openProjectClient).getWorkPackageFromURL)},
+		{"get_work_packages", "Get several OpenProject work packages by ID in one call", bind(client, (*openProjectClient).getWorkPackages)},
+		{"work_package_card", "Get a short fixed-format summary card of an OpenProject work package for pasting into chat", bind(client, (*openProjectClient).workPackageCard)},
+		{"list_work_packages", "List the open and closed work packages of an OpenProject project", bind(client, (*openProjectClient).listWorkPackages)},
+		{"project_status_summary", "Count the work packages of an OpenProject project by status, with open and closed totals", bind(client, (*openProjectClient).projectStatusSummary)},
+		{"recent_activity", "Summarize what changed recently in an OpenProject project: updated work packages with their status and latest comment", bind(client, (*openProjectClient).recentActivity)},
+		{"create_work_package", "Create a new work package in an OpenProject project", bind(client, (*openProjectClient).createWorkPackage)},
+		{"copy_work_package", "Copy an OpenProject work package into a project as a new work package", bind(client, (*openProjectClient).copyWorkPackage)},
+		{"edit_work_package", "Change the subject and/or description of an OpenProject work package", bind(client, (*openProjectClient).editWorkPackage)},
+		{"update_work_package_status", "Change the status of an OpenProject work package", bind(client, (*openProjectClient).updateWorkPackageStatus)},
+		{"bulk_update_status", "Change the status of several OpenProject work packages", bind(client, (*openProjectClient).bulkUpdateStatus)},
+		{"comment_work_package", "Add a markdown comment to an OpenProject work package", bind(client, (*openProjectClient).commentWorkPackage)},
+		{"search_work_packages", "Search OpenProject work packages by text", bind(client, (*openProjectClient).searchWorkPackages)},
+		{"filter_work_packages", "Filter OpenProject work packages by project, status, type and assignee", bind(client, (*openProjectClient).filterWorkPackages)},
+		{"my_work_packages", "List OpenProject work packages assigned to the current user", bind(client, (*openProjectClient).myWorkPackages)},
+		{"list_projects", "List OpenProject projects", bind(client, (*openProjectClient).listProjects)},
+		{"get_project", "Get an OpenProject project by ID or identifier: name, description, status, parent and whether it is active", bind(client, (*openProjectClient).getProject)},
+		{"project_tree", "List OpenProject projects as a parent/child tree", bind(client, (*openProjectClient).projectTree)},
+		{"get_wiki_page", "Get the title, project and attachments of an OpenProject wiki page by ID; API v3 does not expose page text or a page listing", bind(client, (*openProjectClient).getWikiPage)},
+		{"list_forums", "List the forums of an OpenProject project", bind(client, (*openProjectClient).listForums)},
+		{"list_messages", "List the messages of an OpenProject forum with their content", bind(client, (*openProjectClient).listMessages)},
+		{"get_forum_post", "Get an OpenProject forum message with its content by ID", bind(client, (*openProjectClient).getForumPost)},
+		{"list_news", "List recent OpenProject news, optionally for one project", bind(client, (*openProjectClient).listNews)},
+		{"get_news", "Get the full text of an OpenProject news item", bind(client, (*openProjectClient).getNews)},
+		{"log_time", "Log time spent on an OpenProject work package", bind(client, (*openProjectClient).logTime)},
+		{"list_time_entries", "List OpenProject time entries of a work package or user with the total hours", bind(client, (*openProjectClient).listTimeEntries)},
+		{"list_time_entry_activities", "List the activities time can be logged with (the activity_id of log_time)", bind(client, (*openProjectClient).listTimeEntryActivities)},
+		{"list_types", "List OpenProject work package types, globally or for a project", bind(client, (*openProjectClient).listTypes)},
+		{"get_work_package_schema", "Get the required attributes and allowed statuses, priorities and types for a project and work package type", bind(client, (*openProjectClient).getWorkPackageSchema)},
+		{"list_statuses", "List OpenProject work package statuses", bind(client, (*openProjectClient).listStatuses)},
+		{"assign_work_package", "Assign an OpenProject work package to a user, or unassign it", bind(client, (*openProjectClient).assignWorkPackage)},
+		{"list_users", "List OpenProject users, optionally filtered by name", bind(client, (*openProjectClient).listUsers)},
+		{"get_current_user", "Get the OpenProject user the API key belongs to", bind(client, (*openProjectClient).getCurrentUser)},
+		{"list_memberships", "List the members of an OpenProject project (users and groups) with their roles", bind(client, (*openProjectClient).listMemberships)},
+		{"list_roles", "List the roles that can be given to OpenProject project members", bind(client, (*openProjectClient).listRoles)},
+		{"add_membership", "Add a user to an OpenProject project with the given roles", bind(client, (*openProjectClient).addMembership)},
+		{"get_work_package_children", "List the child work packages of an OpenProject work package", bind(client, (*openProjectClient).getWorkPackageChildren)},
+		{"export_work_package_tree", "Export an OpenProject work package and its descendants as a nested tree or outline", bind(client, (*openProjectClient).exportWorkPackageTree)},
+		{"set_progress", "Set the percent complete of an OpenProject work package", bind(client, (*openProjectClient).setProgress)},
+		{"wait_for_status", "Poll an OpenProject work package until it reaches a status or the timeout elapses, then return its state", bind(client, (*openProjectClient).waitForStatus)},
+		{"set_parent", "Move an OpenProject work package under a new parent, or make it top-level", bind(client, (*openProjectClient).setParent)},
+		{"delete_work_package", "Permanently delete an OpenProject work package (requires confirm=true)", bind(client, (*openProjectClient).deleteWorkPackage)},
+		{"list_relations", "List the relations (blocks, relates, precedes, ...) of an OpenProject work package", bind(client, (*openProjectClient).listRelations)},
+		{"create_relation", "Create a relation (blocks, relates, precedes, ...) between two OpenProject work packages", bind(client, (*openProjectClient).createRelation)},
+		{"delete_relation", "Delete an OpenProject work package relation", bind(client, (*openProjectClient).deleteRelation)},
+		{"list_attachments", "List the attachments of an OpenProject work package", bind(client, (*openProjectClient).listAttachments)},
+		{"download_attachment", "Download an OpenProject attachment as base64", bind(client, (*openProjectClient).downloadAttachment)},
+		{"upload_attachment", "Attach a file to an OpenProject work package", bind(client, (*openProjectClient).uploadAttachment)},
+		{"check_connection", "Check that the OpenProject base URL and API key work, and report the instance version and current user", bind(client, (*openProjectClient).checkConnection)},
+		{"list_queries", "List saved OpenProject work package queries (views)", bind(client, (*openProjectClient).listQueries)},
+		{"run_query", "Run a saved OpenProject query and return the matching work packages", bind(client, (*openProjectClient).runQuery)},
+		{"list_priorities", "List OpenProject work package priorities", bind(client, (*openProjectClient).listPriorities)},
+		{"set_priority", "Change the priority of an OpenProject work package", bind(client, (*openProjectClient).setPriority)},
+		{"list_versions", "List the versions (milestones) of an OpenProject project", bind(client, (*openProjectClient).listVersions)},
+		{"create_version", "Create a version (release) in an OpenProject project", bind(client, (*openProjectClient).createVersion)},
+		{"set_version", "Set or clear the target version of an OpenProject work package", bind(client, (*openProjectClient).setVersion)},
+		{"list_categories", "List the work package categories of an OpenProject project", bind(client, (*openProjectClient).listCategories)},
+		{"set_category", "Set or clear the category of an OpenProject work package", bind(client, (*openProjectClient).setCategory)},
+		{"set_custom_field", "Set or clear a custom field of an OpenProject work package", bind(client, (*openProjectClient).setCustomField)},
+		{"get_work_package_activities", "Get the activity history (comments and field changes) of an OpenProject work package", bind(client, (*openProjectClient).getWorkPackageActivities)},
+		{"list_watchers", "List the users watching an OpenProject work package", bind(client, (*openProjectClient).listWatchers)},
+		{"add_watcher", "Add a user as watcher of an OpenProject work package", bind(client, (*openProjectClient).addWatcher)},
+		{"remove_watcher", "Remove a user from the watchers of an OpenProject work package", bind(client, (*openProjectClient).removeWatcher)},
+		{"get_server_stats", "Report tool call counts, OpenProject request errors and latency since the server started", bind(client, (*openProjectClient).getServerStats)},
+	}
+	known := make(map[string]bool, len(tools))
+	for _, tool := range tools {
+		known[tool.name] = true
+	}
+	filter, err := newToolFilter(enableTools, disableTools, known)
 	if err != nil {
 		panic(err)
 	}
+	var skipped []string
+	for _, tool := range tools {
+		if !filter.allowed(tool.name) {
+			skipped = append(skipped, tool.name)
+			continue
+		}
+		if err := server.RegisterTool(tool.name, tool.description, tool.handler(tool.name)); err != nil {
+			panic(err)
+		}
+	}
+
+	if len(skipped) > 0 {
+		logger.Printf("not registering tools %s (see --enable-tools and --disable-tools)", strings.Join(skipped, ", "))
+	}
 
 	err = server.Serve()
 	if err != nil {
 		panic(err)
 	}
+	go syncWorkPackageResources(server, client, watcher.initialized, done)
+
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
+	go func() {
+		sig := <-signals
+		log.Printf("openproject-mcp: received %s, shutting down", sig)
+		close(done)
+	}()
 
 	<-done
+	if sse, ok := serverTransport.(*sseTransport); ok {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := sse.Shutdown(ctx); err != nil {
+			log.Printf("openproject-mcp: SSE shutdown: %v", err)
+		}
+	}
 }
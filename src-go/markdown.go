@@ -0,0 +1,36 @@
+package main
+
+import (
+	"fmt"
+	"strings"
+)
+
+// renderWorkPackageMarkdown renders a work package for display in chat clients
+func renderWorkPackageMarkdown(wp *MinimalWorkPackage) string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "# #%d %s\n\n", wp.ID, wp.Subject)
+
+	assignee := wp.Assignee
+	if assignee == "" {
+		assignee = "Unassigned"
+	}
+	fmt.Fprintf(&b, "**Status:** %s | **Assignee:** %s\n", wp.Status, assignee)
+
+	if description := strings.TrimSpace(wp.Description); description != "" {
+		fmt.Fprintf(&b, "\n%s\n", description)
+	}
+
+	if len(wp.Comments) > 0 {
+		b.WriteString("\n## Comments\n\n")
+		for _, comment := range wp.Comments {
+			// indent continuation lines so multi-line comments stay inside their bullet
+			text := strings.ReplaceAll(strings.TrimSpace(comment.Comment), "\n", "\n  ")
+			if comment.CreatedAt != "" {
+				fmt.Fprintf(&b, "- **%s** (%s): %s\n", comment.Author, comment.CreatedAt, text)
+			} else {
+				fmt.Fprintf(&b, "- **%s**: %s\n", comment.Author, text)
+			}
+		}
+	}
+	return b.String()
+}
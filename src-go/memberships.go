@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListMembershipsArguments struct {
+	ConnectionArguments
+	ProjectID int `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+}
+
+func (a ListMembershipsArguments) validate() error {
+	return positiveID("project_id", a.ProjectID)
+}
+
+type AddMembershipArguments struct {
+	ConnectionArguments
+	ProjectID int   `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+	UserID    int   `json:"user_id" jsonschema:"required,description=User ID to add to the project (see list_users),example=5"`
+	RoleIDs   []int `json:"role_ids" jsonschema:"required,description=Role IDs the user gets in the project (see list_roles)"`
+}
+
+func (a AddMembershipArguments) validate() error {
+	errs := []error{positiveID("project_id", a.ProjectID), positiveID("user_id", a.UserID)}
+	if len(a.RoleIDs) == 0 {
+		errs = append(errs, fmt.Errorf("role_ids must contain at least one role ID"))
+	}
+	for _, id := range a.RoleIDs {
+		errs = append(errs, positiveID("role_ids", id))
+	}
+	return errors.Join(errs...)
+}
+
+type ListRolesArguments struct {
+	ConnectionArguments
+}
+
+type RoleResponse struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type MembershipPayload struct {
+	Links struct {
+		Project   Link   `json:"project"`
+		Principal Link   `json:"principal"`
+		Roles     []Link `json:"roles"`
+	} `json:"_links"`
+}
+
+type MembershipResponse struct {
+	ID    int `json:"id"`
+	Links struct {
+		// a user, a group or a placeholder user
+		Principal Link   `json:"principal"`
+		Roles     []Link `json:"roles"`
+	} `json:"_links"`
+}
+
+type Membership struct {
+	ID            int      `json:"id"`
+	PrincipalType string   `json:"principalType"`
+	PrincipalID   int      `json:"principalId"`
+	Name          string   `json:"name"`
+	Roles         []string `json:"roles"`
+}
+
+func (c *openProjectClient) listMemberships(ctx context.Context, arguments ListMembershipsArguments) (*mcp_golang.ToolResponse, error) {
+	query, err := encodeFilters([]Filter{newFilter("project", "=", strconv.Itoa(arguments.ProjectID))})
+	if err != nil {
+		return nil, err
+	}
+	elements, err := fetchAll[MembershipResponse](ctx, c, "/api/v3/memberships?filters="+query)
+	if err != nil {
+		return nil, err
+	}
+	memberships := make([]Membership, 0, len(elements))
+	for _, m := range elements {
+		memberships = append(memberships, toMembership(m))
+	}
+	return jsonResponse(memberships)
+}
+
+func toMembership(m MembershipResponse) Membership {
+	membership := Membership{
+		ID:            m.ID,
+		PrincipalType: principalType(m.Links.Principal.Href),
+		PrincipalID:   m.Links.Principal.ID(),
+		Name:          m.Links.Principal.Title,
+		Roles:         make([]string, 0, len(m.Links.Roles)),
+	}
+	for _, role := range m.Links.Roles {
+		membership.Roles = append(membership.Roles, role.Title)
+	}
+	return membership
+}
+
+func (c *openProjectClient) listRoles(ctx context.Context, arguments ListRolesArguments) (*mcp_golang.ToolResponse, error) {
+	roles, err := fetchAll[RoleResponse](ctx, c, "/api/v3/roles")
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(roles)
+}
+
+// addMembership adds a user to a project. OpenProject answers a duplicate
+// membership with a generic 422, so on a 422 the existing membership is looked
+// up to report the conflict plainly.
+func (c *openProjectClient) addMembership(ctx context.Context, arguments AddMembershipArguments) (*mcp_golang.ToolResponse, error) {
+	var payload MembershipPayload
+	payload.Links.Project = Link{Href: fmt.Sprintf("/api/v3/projects/%d", arguments.ProjectID)}
+	payload.Links.Principal = Link{Href: fmt.Sprintf("/api/v3/users/%d", arguments.UserID)}
+	for _, id := range arguments.RoleIDs {
+		payload.Links.Roles = append(payload.Links.Roles, Link{Href: fmt.Sprintf("/api/v3/roles/%d", id)})
+	}
+
+	var created MembershipResponse
+	err := c.do(ctx, "POST", "/api/v3/memberships", payload, &created)
+	if isStatus(err, http.StatusUnprocessableEntity) {
+		if existing, lookupErr := c.findMembership(ctx, arguments.ProjectID, arguments.UserID); lookupErr == nil && existing != nil {
+			return nil, fmt.Errorf("user %d is already a member of project %d (membership %d with roles %s), change the roles in OpenProject instead",
+				arguments.UserID, arguments.ProjectID, existing.ID, strings.Join(existing.Roles, ", "))
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(toMembership(created))
+}
+
+// findMembership returns the membership of a principal in a project, nil when there is none
+func (c *openProjectClient) findMembership(ctx context.Context, projectID, principalID int) (*Membership, error) {
+	query, err := encodeFilters([]Filter{
+		newFilter("project", "=", strconv.Itoa(projectID)),
+		newFilter("principal", "=", strconv.Itoa(principalID)),
+	})
+	if err != nil {
+		return nil, err
+	}
+	elements, err := fetchAll[MembershipResponse](ctx, c, "/api/v3/memberships?filters="+query)
+	if err != nil || len(elements) == 0 {
+		return nil, err
+	}
+	membership := toMembership(elements[0])
+	return &membership, nil
+}
+
+// principalType tells users, groups and placeholder users apart by their href,
+// e.g. /api/v3/groups/3 -> "group"
+func principalType(href string) string {
+	switch {
+	case strings.Contains(href, "/groups/"):
+		return "group"
+	case strings.Contains(href, "/placeholder_users/"):
+		return "placeholder"
+	}
+	return "user"
+}
@@ -0,0 +1,130 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// serverMetrics counts tool calls and OpenProject requests. Tools run
+// concurrently under the SSE transport, so every counter is atomic.
+type serverMetrics struct {
+	started time.Time
+
+	requests     atomic.Int64
+	latencyNanos atomic.Int64
+
+	mu sync.Mutex
+	// tool name -> invocations
+	toolCalls map[string]*atomic.Int64
+	// "4xx", "5xx" or "network" -> failed OpenProject requests
+	httpErrors map[string]*atomic.Int64
+}
+
+func newServerMetrics() *serverMetrics {
+	return &serverMetrics{
+		started:    time.Now(),
+		toolCalls:  make(map[string]*atomic.Int64),
+		httpErrors: make(map[string]*atomic.Int64),
+	}
+}
+
+func (m *serverMetrics) counter(counters map[string]*atomic.Int64, key string) *atomic.Int64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	c, ok := counters[key]
+	if !ok {
+		c = new(atomic.Int64)
+		counters[key] = c
+	}
+	return c
+}
+
+func (m *serverMetrics) toolCalled(name string) {
+	m.counter(m.toolCalls, name).Add(1)
+}
+
+// requestDone records one OpenProject round trip; statusCode is 0 when no response arrived
+func (m *serverMetrics) requestDone(statusCode int, elapsed time.Duration) {
+	m.requests.Add(1)
+	m.latencyNanos.Add(int64(elapsed))
+	switch {
+	case statusCode == 0:
+		m.counter(m.httpErrors, "network").Add(1)
+	case statusCode >= 400:
+		m.counter(m.httpErrors, fmt.Sprintf("%dxx", statusCode/100)).Add(1)
+	}
+}
+
+type ServerStats struct {
+	UptimeSeconds    float64          `json:"uptimeSeconds"`
+	ToolCalls        map[string]int64 `json:"toolCalls"`
+	Requests         int64            `json:"requests"`
+	RequestErrors    map[string]int64 `json:"requestErrors"`
+	TotalLatencyMs   float64          `json:"totalLatencyMs"`
+	AverageLatencyMs float64          `json:"averageLatencyMs"`
+}
+
+func (m *serverMetrics) snapshot() ServerStats {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	stats := ServerStats{
+		UptimeSeconds: time.Since(m.started).Seconds(),
+		ToolCalls:     make(map[string]int64, len(m.toolCalls)),
+		Requests:      m.requests.Load(),
+		RequestErrors: make(map[string]int64, len(m.httpErrors)),
+	}
+	for name, c := range m.toolCalls {
+		stats.ToolCalls[name] = c.Load()
+	}
+	for class, c := range m.httpErrors {
+		stats.RequestErrors[class] = c.Load()
+	}
+	total := time.Duration(m.latencyNanos.Load())
+	stats.TotalLatencyMs = float64(total) / float64(time.Millisecond)
+	if stats.Requests > 0 {
+		stats.AverageLatencyMs = stats.TotalLatencyMs / float64(stats.Requests)
+	}
+	return stats
+}
+
+// ServeHTTP renders the counters in the Prometheus text exposition format
+func (m *serverMetrics) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	stats := m.snapshot()
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4")
+	fmt.Fprintln(w, "# TYPE openproject_mcp_tool_calls_total counter")
+	for _, name := range sortedKeys(stats.ToolCalls) {
+		fmt.Fprintf(w, "openproject_mcp_tool_calls_total{tool=%q} %d\n", name, stats.ToolCalls[name])
+	}
+	fmt.Fprintln(w, "# TYPE openproject_mcp_requests_total counter")
+	fmt.Fprintf(w, "openproject_mcp_requests_total %d\n", stats.Requests)
+	fmt.Fprintln(w, "# TYPE openproject_mcp_request_errors_total counter")
+	for _, class := range sortedKeys(stats.RequestErrors) {
+		fmt.Fprintf(w, "openproject_mcp_request_errors_total{class=%q} %d\n", class, stats.RequestErrors[class])
+	}
+	fmt.Fprintln(w, "# TYPE openproject_mcp_request_duration_seconds_total counter")
+	fmt.Fprintf(w, "openproject_mcp_request_duration_seconds_total %g\n", stats.TotalLatencyMs/1000)
+}
+
+func sortedKeys(m map[string]int64) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+type GetServerStatsArguments struct {
+	ConnectionArguments
+}
+
+func (c *openProjectClient) getServerStats(ctx context.Context, arguments GetServerStatsArguments) (*mcp_golang.ToolResponse, error) {
+	return jsonResponse(c.metrics.snapshot())
+}
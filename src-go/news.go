@@ -0,0 +1,97 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListNewsArguments struct {
+	ConnectionArguments
+	ProjectID int `json:"project_id,omitempty" jsonschema:"description=Restrict the news to this project ID (default all projects)"`
+	PageSize  int `json:"page_size,omitempty" jsonschema:"description=Number of most recent news items returned (default 20),minimum=1"`
+}
+
+func (a ListNewsArguments) validate() error {
+	if a.PageSize < 0 {
+		return fmt.Errorf("page_size must not be negative")
+	}
+	return optionalID("project_id", a.ProjectID)
+}
+
+type GetNewsArguments struct {
+	ConnectionArguments
+	NewsID int `json:"news_id" jsonschema:"required,description=News ID of OpenProject (see list_news)"`
+}
+
+func (a GetNewsArguments) validate() error {
+	return positiveID("news_id", a.NewsID)
+}
+
+type NewsResponse struct {
+	ID          int         `json:"id"`
+	Title       string      `json:"title"`
+	Summary     string      `json:"summary"`
+	Description Formattable `json:"description"`
+	CreatedAt   string      `json:"createdAt"`
+	Links       struct {
+		Project Link `json:"project"`
+		Author  Link `json:"author"`
+	} `json:"_links"`
+}
+
+type News struct {
+	ID          int    `json:"id"`
+	Title       string `json:"title"`
+	Summary     string `json:"summary,omitempty"`
+	Author      string `json:"author"`
+	Project     string `json:"project,omitempty"`
+	CreatedAt   string `json:"createdAt"`
+	Description string `json:"description,omitempty"`
+}
+
+func toNews(n NewsResponse) News {
+	return News{ID: n.ID, Title: n.Title, Summary: n.Summary, Author: n.Links.Author.Title, Project: n.Links.Project.Title, CreatedAt: n.CreatedAt}
+}
+
+// listNews returns the newest news items without their full text, see getNews
+func (c *openProjectClient) listNews(ctx context.Context, arguments ListNewsArguments) (*mcp_golang.ToolResponse, error) {
+	pageSize := arguments.PageSize
+	if pageSize == 0 {
+		pageSize = 20
+	}
+	sortBy, err := encodeSortBy("-createdAt")
+	if err != nil {
+		return nil, err
+	}
+	path := fmt.Sprintf("/api/v3/news?sortBy=%s&pageSize=%d", sortBy, pageSize)
+	if arguments.ProjectID != 0 {
+		filters, err := encodeFilters([]Filter{newFilter("project_id", "=", strconv.Itoa(arguments.ProjectID))})
+		if err != nil {
+			return nil, err
+		}
+		path += "&filters=" + filters
+	}
+
+	var collection CollectionPage[NewsResponse]
+	if err := c.get(ctx, path, &collection); err != nil {
+		return nil, err
+	}
+	news := make([]News, 0, len(collection.Embedded.Elements))
+	for _, n := range collection.Embedded.Elements {
+		news = append(news, toNews(n))
+	}
+	return jsonResponse(news)
+}
+
+func (c *openProjectClient) getNews(ctx context.Context, arguments GetNewsArguments) (*mcp_golang.ToolResponse, error) {
+	var n NewsResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v3/news/%d", arguments.NewsID), &n); err != nil {
+		return nil, err
+	}
+	news := toNews(n)
+	news.Description = n.Description.Raw
+	return jsonResponse(news)
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// CollectionPage is the paging envelope shared by all OpenProject collections
+type CollectionPage[T any] struct {
+	Total    int `json:"total"`
+	Count    int `json:"count"`
+	PageSize int `json:"pageSize"`
+	Offset   int `json:"offset"`
+	Embedded struct {
+		Elements []T `json:"elements"`
+	} `json:"_embedded"`
+	Links struct {
+		NextByOffset *Link `json:"nextByOffset"`
+	} `json:"_links"`
+}
+
+// errTooManyPages is returned by fetchAll when a collection has more than --max-pages pages
+var errTooManyPages = errors.New("openproject: collection exceeds --max-pages")
+
+// fetchAll follows `nextByOffset` links from path until every element has been
+// gathered. It gives up after c.maxPages pages, as a guard against runaway
+// loops, with an error rather than partial results that would pass for complete.
+func fetchAll[T any](ctx context.Context, c *openProjectClient, path string) ([]T, error) {
+	var elements []T
+	total := 0
+	for page := 0; path != ""; page++ {
+		if page == c.maxPages {
+			c.logf(ctx, "stopped paging %s after %d pages (--max-pages)", path, c.maxPages)
+			return nil, fmt.Errorf("%w: fetched %d of %d items in %d pages; raise --max-pages or narrow the query", errTooManyPages, len(elements), total, c.maxPages)
+		}
+		var collection CollectionPage[T]
+		if err := c.get(ctx, path, &collection); err != nil {
+			return nil, err
+		}
+		elements = append(elements, collection.Embedded.Elements...)
+		total = collection.Total
+
+		path = ""
+		if next := collection.Links.NextByOffset; next != nil && next.Href != "" && len(collection.Embedded.Elements) > 0 {
+			path = c.hrefPath(next.Href)
+		}
+	}
+	return elements, nil
+}
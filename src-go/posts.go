@@ -0,0 +1,124 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListForumsArguments struct {
+	ConnectionArguments
+	ProjectID int `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+}
+
+func (a ListForumsArguments) validate() error {
+	return positiveID("project_id", a.ProjectID)
+}
+
+type ListMessagesArguments struct {
+	ConnectionArguments
+	ForumID int `json:"forum_id" jsonschema:"required,description=Forum ID of OpenProject (see list_forums)"`
+}
+
+func (a ListMessagesArguments) validate() error {
+	return positiveID("forum_id", a.ForumID)
+}
+
+type GetForumPostArguments struct {
+	ConnectionArguments
+	PostID int `json:"post_id" jsonschema:"required,description=Forum message ID of OpenProject (see list_messages, or the number in a /topics/ link)"`
+}
+
+func (a GetForumPostArguments) validate() error {
+	return positiveID("post_id", a.PostID)
+}
+
+// ForumResponse is a forum (board) of a project
+type ForumResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+}
+
+type Forum struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+}
+
+// PostResponse is the API v3 post, a message in a project forum. A reply links
+// to the message that opened its thread as parent.
+type PostResponse struct {
+	ID        int         `json:"id"`
+	Subject   string      `json:"subject"`
+	Content   Formattable `json:"content"`
+	CreatedAt string      `json:"createdAt"`
+	Links     struct {
+		Project Link `json:"project"`
+		Author  Link `json:"author"`
+		Parent  Link `json:"parent"`
+	} `json:"_links"`
+}
+
+func (p *PostResponse) halType() string { return "Post" }
+
+type ForumPost struct {
+	ID        int    `json:"id"`
+	Subject   string `json:"subject"`
+	ProjectID int    `json:"projectId,omitempty"`
+	Project   string `json:"project,omitempty"`
+	Author    string `json:"author,omitempty"`
+	ParentID  int    `json:"parentId,omitempty"`
+	CreatedAt string `json:"createdAt,omitempty"`
+	Content   string `json:"content"`
+}
+
+func toForumPost(p PostResponse) ForumPost {
+	return ForumPost{
+		ID:        p.ID,
+		Subject:   p.Subject,
+		ProjectID: p.Links.Project.ID(),
+		Project:   p.Links.Project.Title,
+		Author:    p.Links.Author.Title,
+		ParentID:  p.Links.Parent.ID(),
+		CreatedAt: p.CreatedAt,
+		Content:   p.Content.Raw,
+	}
+}
+
+// listForums returns every forum of a project, see listMessages for their messages
+func (c *openProjectClient) listForums(ctx context.Context, arguments ListForumsArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[ForumResponse](ctx, c, fmt.Sprintf("/api/v3/projects/%d/forums", arguments.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+	forums := make([]Forum, 0, len(elements))
+	for _, f := range elements {
+		forums = append(forums, Forum{ID: f.ID, Name: f.Name, Description: f.Description})
+	}
+	return jsonResponse(forums)
+}
+
+// listMessages returns every message of a forum with its content, in the order
+// OpenProject lists them; replies carry the ID of their thread's first message
+func (c *openProjectClient) listMessages(ctx context.Context, arguments ListMessagesArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[PostResponse](ctx, c, fmt.Sprintf("/api/v3/forums/%d/posts", arguments.ForumID))
+	if err != nil {
+		return nil, err
+	}
+	posts := make([]ForumPost, 0, len(elements))
+	for _, p := range elements {
+		posts = append(posts, toForumPost(p))
+	}
+	return jsonResponse(posts)
+}
+
+// getForumPost reads a single forum message with its content
+func (c *openProjectClient) getForumPost(ctx context.Context, arguments GetForumPostArguments) (*mcp_golang.ToolResponse, error) {
+	var post PostResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v3/posts/%d", arguments.PostID), &post); err != nil {
+		return nil, err
+	}
+	return jsonResponse(toForumPost(post))
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListPrioritiesArguments struct {
+	ConnectionArguments
+}
+
+type SetPriorityArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	PriorityID    int `json:"priority_id" jsonschema:"required,description=ID of the new priority (see list_priorities)"`
+}
+
+func (a SetPriorityArguments) validate() error {
+	return errors.Join(
+		positiveID("work_package_id", a.WorkPackageID),
+		positiveID("priority_id", a.PriorityID),
+	)
+}
+
+type PriorityResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Position  int    `json:"position"`
+	IsDefault bool   `json:"isDefault"`
+	IsActive  bool   `json:"isActive"`
+}
+
+type Priority struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Position  int    `json:"position"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+func (c *openProjectClient) listPriorities(ctx context.Context, arguments ListPrioritiesArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[PriorityResponse](ctx, c, "/api/v3/priorities")
+	if err != nil {
+		return nil, err
+	}
+	priorities := make([]Priority, 0, len(elements))
+	for _, p := range elements {
+		if !p.IsActive {
+			continue
+		}
+		priorities = append(priorities, Priority{ID: p.ID, Name: p.Name, Position: p.Position, IsDefault: p.IsDefault})
+	}
+	return jsonResponse(priorities)
+}
+
+func (c *openProjectClient) setPriority(ctx context.Context, arguments SetPriorityArguments) (*mcp_golang.ToolResponse, error) {
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, WorkPackagePayload{
+		LockVersion: arguments.LockVersion,
+		Links: map[string]*Link{
+			"priority": {Href: fmt.Sprintf("/api/v3/priorities/%d", arguments.PriorityID)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID       int    `json:"id"`
+		Priority string `json:"priority"`
+	}{updated.ID, updated.Links.Priority.Title})
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strconv"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListProjectsArguments struct {
+	ConnectionArguments
+	ActiveOnly bool `json:"active_only,omitempty" jsonschema:"description=Exclude archived projects (default false)"`
+}
+
+type ProjectTreeArguments struct {
+	ConnectionArguments
+	ActiveOnly bool `json:"active_only,omitempty" jsonschema:"description=Exclude archived projects (default false)"`
+}
+
+type GetProjectArguments struct {
+	ConnectionArguments
+	ProjectID  int    `json:"project_id,omitempty" jsonschema:"description=Project ID of OpenProject; give either this or identifier,example=7"`
+	Identifier string `json:"identifier,omitempty" jsonschema:"description=Project identifier as shown in project URLs; give either this or project_id,example=demo-project"`
+}
+
+func (a GetProjectArguments) validate() error {
+	if (a.ProjectID == 0) == (a.Identifier == "") {
+		return errors.New("give exactly one of project_id and identifier")
+	}
+	return optionalID("project_id", a.ProjectID)
+}
+
+type ProjectResponse struct {
+	ID                int         `json:"id"`
+	Name              string      `json:"name"`
+	Identifier        string      `json:"identifier"`
+	Active            bool        `json:"active"`
+	Description       Formattable `json:"description"`
+	StatusExplanation Formattable `json:"statusExplanation"`
+	Links             struct {
+		Parent Link `json:"parent"`
+		// e.g. /api/v3/project_statuses/on_track, null when no status is set
+		Status Link `json:"status"`
+	} `json:"_links"`
+}
+
+func (p *ProjectResponse) halType() string { return "Project" }
+
+type Project struct {
+	ID         int    `json:"id"`
+	Name       string `json:"name"`
+	Identifier string `json:"identifier"`
+	Active     bool   `json:"active"`
+}
+
+type ProjectDetails struct {
+	ID                int    `json:"id"`
+	Name              string `json:"name"`
+	Identifier        string `json:"identifier"`
+	Active            bool   `json:"active"`
+	Description       string `json:"description,omitempty"`
+	Status            string `json:"status,omitempty"`
+	StatusExplanation string `json:"statusExplanation,omitempty"`
+	ParentID          int    `json:"parentId,omitempty"`
+	Parent            string `json:"parent,omitempty"`
+}
+
+type ProjectNode struct {
+	ID       int            `json:"id"`
+	Name     string         `json:"name"`
+	Children []*ProjectNode `json:"children,omitempty"`
+}
+
+func (c *openProjectClient) fetchProjects(ctx context.Context, activeOnly bool) ([]ProjectResponse, error) {
+	path := "/api/v3/projects"
+	if activeOnly {
+		query, err := encodeFilters([]Filter{newFilter("active", "=", "t")})
+		if err != nil {
+			return nil, err
+		}
+		path += "?filters=" + query
+	}
+	return fetchAll[ProjectResponse](ctx, c, path)
+}
+
+func (c *openProjectClient) listProjects(ctx context.Context, arguments ListProjectsArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := c.fetchProjects(ctx, arguments.ActiveOnly)
+	if err != nil {
+		return nil, err
+	}
+	projects := make([]Project, 0, len(elements))
+	for _, p := range elements {
+		projects = append(projects, Project{ID: p.ID, Name: p.Name, Identifier: p.Identifier, Active: p.Active})
+	}
+	return jsonResponse(projects)
+}
+
+// getProject accepts the identifier as well, since it is what project URLs show
+// and, unlike the ID, usually the same across instances
+func (c *openProjectClient) getProject(ctx context.Context, arguments GetProjectArguments) (*mcp_golang.ToolResponse, error) {
+	idOrIdentifier := arguments.Identifier
+	if arguments.ProjectID != 0 {
+		idOrIdentifier = strconv.Itoa(arguments.ProjectID)
+	}
+	var p ProjectResponse
+	if err := c.getCached(ctx, "/api/v3/projects/"+url.PathEscape(idOrIdentifier), &p); err != nil {
+		return nil, err
+	}
+	return jsonResponse(ProjectDetails{
+		ID:                p.ID,
+		Name:              p.Name,
+		Identifier:        p.Identifier,
+		Active:            p.Active,
+		Description:       p.Description.Raw,
+		Status:            p.Links.Status.Title,
+		StatusExplanation: p.StatusExplanation.Raw,
+		ParentID:          p.Links.Parent.ID(),
+		Parent:            p.Links.Parent.Title,
+	})
+}
+
+func (c *openProjectClient) projectTree(ctx context.Context, arguments ProjectTreeArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := c.fetchProjects(ctx, arguments.ActiveOnly)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(buildProjectTree(elements))
+}
+
+// buildProjectTree nests projects under their parents. Projects whose parent is
+// not visible to the user become roots, and members of a (malformed) parent
+// cycle are cut loose at the first one reached, so every project appears exactly once.
+func buildProjectTree(projects []ProjectResponse) []*ProjectNode {
+	nodes := make(map[int]*ProjectNode, len(projects))
+	for _, p := range projects {
+		nodes[p.ID] = &ProjectNode{ID: p.ID, Name: p.Name}
+	}
+	children := make(map[int][]int)
+	var roots []int
+	for _, p := range projects {
+		parent := p.Links.Parent.ID()
+		if _, ok := nodes[parent]; ok && parent != p.ID {
+			children[parent] = append(children[parent], p.ID)
+		} else {
+			roots = append(roots, p.ID)
+		}
+	}
+
+	placed := make(map[int]bool, len(projects))
+	var attach func(id int) *ProjectNode
+	attach = func(id int) *ProjectNode {
+		placed[id] = true
+		node := nodes[id]
+		for _, child := range children[id] {
+			if !placed[child] {
+				node.Children = append(node.Children, attach(child))
+			}
+		}
+		return node
+	}
+	tree := make([]*ProjectNode, 0, len(roots))
+	for _, id := range roots {
+		tree = append(tree, attach(id))
+	}
+	for _, p := range projects {
+		if !placed[p.ID] {
+			tree = append(tree, attach(p.ID))
+		}
+	}
+	return tree
+}
@@ -0,0 +1,131 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListQueriesArguments struct {
+	ConnectionArguments
+}
+
+type RunQueryArguments struct {
+	ConnectionArguments
+	QueryID int `json:"query_id" jsonschema:"required,description=Saved query ID of OpenProject (see list_queries)"`
+}
+
+func (a RunQueryArguments) validate() error {
+	return positiveID("query_id", a.QueryID)
+}
+
+type QueryResponse struct {
+	ID      int                   `json:"id"`
+	Name    string                `json:"name"`
+	Public  bool                  `json:"public"`
+	Starred bool                  `json:"starred"`
+	Filters []QueryFilterResponse `json:"filters"`
+	Links   struct {
+		Project Link   `json:"project"`
+		SortBy  []Link `json:"sortBy"`
+	} `json:"_links"`
+}
+
+// QueryFilterResponse is a filter as stored on a saved query: the filter and
+// operator are links, values are plain strings or links depending on the filter
+type QueryFilterResponse struct {
+	Values []string `json:"values"`
+	Links  struct {
+		Filter   Link   `json:"filter"`
+		Operator Link   `json:"operator"`
+		Values   []Link `json:"values"`
+	} `json:"_links"`
+}
+
+type Query struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Public    bool   `json:"public"`
+	Starred   bool   `json:"starred"`
+	ProjectID int    `json:"projectId,omitempty"`
+}
+
+func (c *openProjectClient) listQueries(ctx context.Context, arguments ListQueriesArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[QueryResponse](ctx, c, "/api/v3/queries")
+	if err != nil {
+		return nil, err
+	}
+	queries := make([]Query, 0, len(elements))
+	for _, q := range elements {
+		queries = append(queries, Query{ID: q.ID, Name: q.Name, Public: q.Public, Starred: q.Starred, ProjectID: q.Links.Project.ID()})
+	}
+	return jsonResponse(queries)
+}
+
+// runQuery re-executes a saved query's filters and sort order against the work
+// packages endpoint, scoped to the query's project if it has one
+func (c *openProjectClient) runQuery(ctx context.Context, arguments RunQueryArguments) (*mcp_golang.ToolResponse, error) {
+	var query QueryResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v3/queries/%d", arguments.QueryID), &query); err != nil {
+		return nil, err
+	}
+
+	filters := make([]Filter, 0, len(query.Filters))
+	for _, f := range query.Filters {
+		values := f.Values
+		if len(f.Links.Values) > 0 {
+			values = make([]string, 0, len(f.Links.Values))
+			for _, v := range f.Links.Values {
+				values = append(values, lastPathSegment(v.Href))
+			}
+		}
+		filters = append(filters, newFilter(lastPathSegment(f.Links.Filter.Href), lastPathSegment(f.Links.Operator.Href), values...))
+	}
+	encoded, err := encodeFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/api/v3/work_packages"
+	if project := query.Links.Project.ID(); project != 0 {
+		path = fmt.Sprintf("/api/v3/projects/%d/work_packages", project)
+	}
+	path += "?filters=" + encoded
+	if len(query.Links.SortBy) > 0 {
+		// sort_bys hrefs look like /api/v3/queries/sort_bys/updatedAt-desc
+		sortBy := make([][2]string, 0, len(query.Links.SortBy))
+		for _, s := range query.Links.SortBy {
+			segment := lastPathSegment(s.Href)
+			i := strings.LastIndex(segment, "-")
+			if i < 0 {
+				continue
+			}
+			sortBy = append(sortBy, [2]string{segment[:i], segment[i+1:]})
+		}
+		data, err := json.Marshal(sortBy)
+		if err != nil {
+			return nil, err
+		}
+		path += "&sortBy=" + url.QueryEscape(string(data))
+	}
+
+	elements, err := fetchAll[WorkPackageResponse](ctx, c, path)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(summarizeWorkPackages(elements))
+}
+
+// lastPathSegment returns the unescaped last segment of an href,
+// e.g. "=" for /api/v3/queries/operators/%3D
+func lastPathSegment(href string) string {
+	segment := href[strings.LastIndex(href, "/")+1:]
+	if unescaped, err := url.PathUnescape(segment); err == nil {
+		return unescaped
+	}
+	return segment
+}
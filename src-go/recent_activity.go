@@ -0,0 +1,105 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// commentExcerptLength is the number of characters of the last comment recent_activity returns
+const commentExcerptLength = 200
+
+type RecentActivityArguments struct {
+	ConnectionArguments
+	ProjectID int    `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+	Since     string `json:"since" jsonschema:"required,description=Only work packages updated at or after this ISO 8601 timestamp or date,example=2024-05-01T09:00:00Z,example=2024-05-01"`
+	Limit     int    `json:"limit,omitempty" jsonschema:"description=Maximum number of work packages returned, most recently updated first (default 20),minimum=1,maximum=100"`
+}
+
+func (a RecentActivityArguments) validate() error {
+	errs := []error{positiveID("project_id", a.ProjectID)}
+	if _, err := parseSince(a.Since); err != nil {
+		errs = append(errs, err)
+	}
+	if a.Limit < 0 || a.Limit > 100 {
+		errs = append(errs, fmt.Errorf("limit must be between 1 and 100"))
+	}
+	return errors.Join(errs...)
+}
+
+// parseSince accepts an RFC 3339 timestamp or a date, which means midnight UTC
+func parseSince(since string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, since); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.DateOnly, since)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("since must be an ISO 8601 timestamp (2024-05-01T09:00:00Z) or date (2024-05-01)")
+	}
+	return t, nil
+}
+
+// RecentChange is one entry of the recent_activity digest
+type RecentChange struct {
+	ID            int    `json:"id"`
+	Subject       string `json:"subject"`
+	Status        string `json:"status"`
+	UpdatedAt     string `json:"updatedAt"`
+	LastComment   string `json:"lastComment,omitempty"`
+	LastCommentBy string `json:"lastCommentBy,omitempty"`
+	Error         string `json:"error,omitempty"`
+}
+
+// recentActivity lists the work packages of a project updated since a point in
+// time, newest first, with an excerpt of each one's latest comment. Collections
+// do not embed activities, so those are fetched per work package; a failure
+// there is reported on the entry instead of failing the digest.
+func (c *openProjectClient) recentActivity(ctx context.Context, arguments RecentActivityArguments) (*mcp_golang.ToolResponse, error) {
+	since, _ := parseSince(arguments.Since)
+	limit := arguments.Limit
+	if limit == 0 {
+		limit = 20
+	}
+	query, err := encodeFilters([]Filter{newFilter("updatedAt", "<>d", since.UTC().Format(time.RFC3339), "")})
+	if err != nil {
+		return nil, err
+	}
+	sortBy, err := encodeSortBy("-updatedAt")
+	if err != nil {
+		return nil, err
+	}
+	var collection CollectionPage[WorkPackageResponse]
+	path := fmt.Sprintf("/api/v3/projects/%d/work_packages?filters=%s&sortBy=%s&pageSize=%d", arguments.ProjectID, query, sortBy, limit)
+	fields := []string{"elements/id", "elements/subject", "elements/status", "elements/updatedAt"}
+	if err := c.getSelected(ctx, path, fields, &collection); err != nil {
+		return nil, err
+	}
+
+	elements := collection.Embedded.Elements
+	changes := make([]RecentChange, len(elements))
+	c.forEach(len(elements), func(i int) {
+		wpResp := elements[i]
+		changes[i] = RecentChange{
+			ID:        wpResp.ID,
+			Subject:   wpResp.Subject,
+			Status:    wpResp.Links.Status.Title,
+			UpdatedAt: wpResp.UpdatedAt,
+		}
+		activities, err := fetchAll[ActivityResponse](ctx, c, fmt.Sprintf("/api/v3/work_packages/%d/activities", wpResp.ID))
+		if err != nil {
+			changes[i].Error = err.Error()
+			return
+		}
+		for j := len(activities) - 1; j >= 0; j-- {
+			if comment := activities[j].Comment.Raw; comment != "" {
+				changes[i].LastComment = excerpt(comment, commentExcerptLength)
+				changes[i].LastCommentBy = activities[j].Links.User.Title
+				break
+			}
+		}
+	})
+	return jsonResponse(changes)
+}
@@ -0,0 +1,120 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListRelationsArguments struct {
+	ConnectionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+}
+
+func (a ListRelationsArguments) validate() error {
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+// relation types accepted by OpenProject, as seen from the "from" work package
+var relationTypes = []string{"relates", "duplicates", "duplicated", "blocks", "blocked", "precedes", "follows", "includes", "partof", "requires", "required"}
+
+type CreateRelationArguments struct {
+	ConnectionArguments
+	FromID       int    `json:"from_id" jsonschema:"required,description=Work Package ID the relation starts from"`
+	ToID         int    `json:"to_id" jsonschema:"required,description=Work Package ID the relation points to"`
+	RelationType string `json:"relation_type" jsonschema:"required,description=Relation type as seen from from_id,enum=relates,enum=duplicates,enum=duplicated,enum=blocks,enum=blocked,enum=precedes,enum=follows,enum=includes,enum=partof,enum=requires,enum=required"`
+	Description  string `json:"description,omitempty" jsonschema:"description=Free text describing the relation"`
+}
+
+func (a CreateRelationArguments) validate() error {
+	var typeErr error
+	if !slices.Contains(relationTypes, a.RelationType) {
+		typeErr = fmt.Errorf("relation_type must be one of %s", strings.Join(relationTypes, ", "))
+	}
+	return errors.Join(
+		positiveID("from_id", a.FromID),
+		positiveID("to_id", a.ToID),
+		typeErr,
+	)
+}
+
+type DeleteRelationArguments struct {
+	ConnectionArguments
+	RelationID int `json:"relation_id" jsonschema:"required,description=Relation ID (see list_relations)"`
+}
+
+func (a DeleteRelationArguments) validate() error {
+	return positiveID("relation_id", a.RelationID)
+}
+
+type RelationResponse struct {
+	ID          int    `json:"id"`
+	Type        string `json:"type"`
+	ReverseType string `json:"reverseType"`
+	Links       struct {
+		From Link `json:"from"`
+		To   Link `json:"to"`
+	} `json:"_links"`
+}
+
+type Relation struct {
+	ID int `json:"id"`
+	// Type is read from the requested work package's side, e.g. "blocks" or "blocked"
+	Type           string `json:"type"`
+	Direction      string `json:"direction"`
+	RelatedID      int    `json:"relatedId"`
+	RelatedSubject string `json:"relatedSubject"`
+}
+
+func (c *openProjectClient) listRelations(ctx context.Context, arguments ListRelationsArguments) (*mcp_golang.ToolResponse, error) {
+	path := fmt.Sprintf("/api/v3/work_packages/%d/relations", arguments.WorkPackageID)
+	elements, err := fetchAll[RelationResponse](ctx, c, path)
+	if err != nil {
+		return nil, err
+	}
+	relations := make([]Relation, 0, len(elements))
+	for _, r := range elements {
+		relation := Relation{ID: r.ID, Type: r.Type, Direction: "outgoing", RelatedID: r.Links.To.ID(), RelatedSubject: r.Links.To.Title}
+		if r.Links.From.ID() != arguments.WorkPackageID {
+			relation = Relation{ID: r.ID, Type: r.ReverseType, Direction: "incoming", RelatedID: r.Links.From.ID(), RelatedSubject: r.Links.From.Title}
+		}
+		relations = append(relations, relation)
+	}
+	return jsonResponse(relations)
+}
+
+func (c *openProjectClient) createRelation(ctx context.Context, arguments CreateRelationArguments) (*mcp_golang.ToolResponse, error) {
+	payload := struct {
+		Type        string          `json:"type"`
+		Description string          `json:"description,omitempty"`
+		Links       map[string]Link `json:"_links"`
+	}{
+		Type:        arguments.RelationType,
+		Description: arguments.Description,
+		Links:       map[string]Link{"to": {Href: fmt.Sprintf("/api/v3/work_packages/%d", arguments.ToID)}},
+	}
+	var created RelationResponse
+	if err := c.do(ctx, "POST", fmt.Sprintf("/api/v3/work_packages/%d/relations", arguments.FromID), payload, &created); err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID     int    `json:"id"`
+		Type   string `json:"type"`
+		FromID int    `json:"fromId"`
+		ToID   int    `json:"toId"`
+	}{created.ID, created.Type, created.Links.From.ID(), created.Links.To.ID()})
+}
+
+func (c *openProjectClient) deleteRelation(ctx context.Context, arguments DeleteRelationArguments) (*mcp_golang.ToolResponse, error) {
+	if err := c.do(ctx, "DELETE", fmt.Sprintf("/api/v3/relations/%d", arguments.RelationID), nil, nil); err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID      int  `json:"id"`
+		Deleted bool `json:"deleted"`
+	}{arguments.RelationID, true})
+}
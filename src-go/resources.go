@@ -0,0 +1,125 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+	"github.com/metoro-io/mcp-golang/transport"
+)
+
+// number of recently updated work packages exposed as resources
+const recentWorkPackageResources = 50
+
+// how often the list of recently updated work packages is refetched
+const resourceRefreshInterval = 5 * time.Minute
+
+func workPackageURI(id int) string {
+	return fmt.Sprintf("openproject://work_package/%d", id)
+}
+
+// initializeWatcher wraps the server transport to tell when a client has
+// completed the MCP handshake, that is sent notifications/initialized.
+type initializeWatcher struct {
+	transport.Transport
+	once        sync.Once
+	initialized chan struct{}
+}
+
+func newInitializeWatcher(t transport.Transport) *initializeWatcher {
+	return &initializeWatcher{Transport: t, initialized: make(chan struct{})}
+}
+
+func (w *initializeWatcher) SetMessageHandler(handler func(ctx context.Context, message *transport.BaseJsonRpcMessage)) {
+	w.Transport.SetMessageHandler(func(ctx context.Context, message *transport.BaseJsonRpcMessage) {
+		handler(ctx, message)
+		if message.JsonRpcNotification != nil && message.JsonRpcNotification.Method == "notifications/initialized" {
+			w.once.Do(func() { close(w.initialized) })
+		}
+	})
+}
+
+// syncWorkPackageResources keeps one concrete resource per recently updated work
+// package. No URI template is advertised because mcp-golang only resolves exact
+// URIs, so work packages outside the list are read with the tools instead.
+// It waits until a client has initialized, since each change is announced by
+// mcp-golang with a resources/list_changed notification, and so a slow or
+// unreachable OpenProject never delays the handshake. The list is refetched
+// every resourceRefreshInterval until done is closed.
+func syncWorkPackageResources(server *mcp_golang.Server, client *openProjectClient, initialized, done <-chan struct{}) {
+	select {
+	case <-done:
+		return
+	case <-initialized:
+	}
+	// resource names by work package ID, as currently registered
+	registered := map[int]string{}
+	ticker := time.NewTicker(resourceRefreshInterval)
+	defer ticker.Stop()
+	for {
+		// resources are a convenience; a listing failure keeps the previous list
+		if err := updateWorkPackageResources(context.Background(), server, client, registered); err != nil {
+			log.Printf("openproject-mcp: could not refresh work package resources: %v", err)
+		}
+		select {
+		case <-done:
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// updateWorkPackageResources registers the recently updated work packages that
+// are new or renamed and removes those that dropped out of the list. Each
+// resource resolves to the same JSON as get_detail_work_package_by_id.
+func updateWorkPackageResources(ctx context.Context, server *mcp_golang.Server, client *openProjectClient, registered map[int]string) error {
+	sortBy, err := encodeSortBy("-updatedAt")
+	if err != nil {
+		return err
+	}
+	var collection CollectionPage[WorkPackageResponse]
+	path := fmt.Sprintf("/api/v3/work_packages?sortBy=%s&pageSize=%d", sortBy, recentWorkPackageResources)
+	if err := client.get(ctx, path, &collection); err != nil {
+		return err
+	}
+
+	current := make(map[int]bool, len(collection.Embedded.Elements))
+	for _, wpResp := range collection.Embedded.Elements {
+		id := wpResp.ID
+		current[id] = true
+		name := fmt.Sprintf("#%d %s", id, wpResp.Subject)
+		if registered[id] == name {
+			continue
+		}
+		uri := workPackageURI(id)
+		err := server.RegisterResource(uri, name, "Recently updated OpenProject work package", "application/json", func(ctx context.Context) (*mcp_golang.ResourceResponse, error) {
+			wp, err := client.fetchMinimalWorkPackage(ctx, CurlToolArguments{WorkPackageID: id})
+			if err != nil {
+				return nil, err
+			}
+			result, err := json.Marshal(wp)
+			if err != nil {
+				return nil, err
+			}
+			return mcp_golang.NewResourceResponse(mcp_golang.NewTextEmbeddedResource(uri, string(result), "application/json")), nil
+		})
+		if err != nil {
+			return err
+		}
+		registered[id] = name
+	}
+	for id := range registered {
+		if current[id] {
+			continue
+		}
+		if err := server.DeregisterResource(workPackageURI(id)); err != nil {
+			return err
+		}
+		delete(registered, id)
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package main
+
+import (
+	"regexp"
+	"strings"
+)
+
+var (
+	// <macro class="toc"></macro>, <macro class="embedded-table" ... /> and friends
+	macroTag = regexp.MustCompile(`(?is)<macro\b[^>]*?(?:/>|>.*?</macro>)`)
+	// the older brace syntax: {{toc}}, {{>toc}}, {{child_pages(depth=1)}}
+	macroBraces = regexp.MustCompile(`\{\{[<>]?[a-z_]+(?:\([^)]*\))?\}\}`)
+	// inline images as the OpenProject editor stores them: <figure class="image"><img src="/api/v3/attachments/..."></figure>
+	figureTag = regexp.MustCompile(`(?is)<figure\b[^>]*>.*?</figure>`)
+	imgTag    = regexp.MustCompile(`(?i)<img\b[^>]*>`)
+	blankRuns = regexp.MustCompile(`\n[ \t]*\n(?:[ \t]*\n)+`)
+)
+
+// cleanDescription strips OpenProject macros and embedded image markup and
+// collapses runs of blank lines. Markdown itself, including trailing-space
+// line breaks, is left untouched.
+func cleanDescription(text string) string {
+	text = strings.ReplaceAll(text, "\r\n", "\n")
+	text = macroTag.ReplaceAllString(text, "")
+	text = macroBraces.ReplaceAllString(text, "")
+	text = figureTag.ReplaceAllString(text, "")
+	text = imgTag.ReplaceAllString(text, "")
+	text = blankRuns.ReplaceAllString(text, "\n\n")
+	return strings.TrimSpace(text)
+}
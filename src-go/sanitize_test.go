@@ -0,0 +1,54 @@
+package main
+
+import "testing"
+
+func TestCleanDescription(t *testing.T) {
+	tests := []struct {
+		name string
+		in   string
+		want string
+	}{
+		{
+			name: "toc macro",
+			in:   "{{toc}}\n\n# Title\n\nText",
+			want: "# Title\n\nText",
+		},
+		{
+			name: "toc macro tag",
+			in:   "<macro class=\"toc\"></macro>\n\nText",
+			want: "Text",
+		},
+		{
+			name: "self-closing embedded table",
+			in:   "Before\n\n<macro class=\"embedded-table\" data-query-props=\"{&quot;columns&quot;:[]}\" />\n\nAfter",
+			want: "Before\n\nAfter",
+		},
+		{
+			name: "figure with image",
+			in:   "See:\n\n<figure class=\"image\"><img src=\"/api/v3/attachments/1/content\"></figure>\n\nDone",
+			want: "See:\n\nDone",
+		},
+		{
+			name: "bare img",
+			in:   "Logo <img src=\"/api/v3/attachments/2/content\" alt=\"logo\"> here",
+			want: "Logo  here",
+		},
+		{
+			name: "blank line runs",
+			in:   "One\n\n\n\nTwo\r\n\r\n\r\nThree\n \n\t\nFour",
+			want: "One\n\nTwo\n\nThree\n\nFour",
+		},
+		{
+			name: "plain markdown unchanged",
+			in:   "# Heading\n\n- item one\n- item two\n\n```go\nfmt.Println(\"{{not a macro}}\")\n```\n\nline with break  \nnext line",
+			want: "# Heading\n\n- item one\n- item two\n\n```go\nfmt.Println(\"{{not a macro}}\")\n```\n\nline with break  \nnext line",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := cleanDescription(tt.in); got != tt.want {
+				t.Errorf("cleanDescription(%q) = %q, want %q", tt.in, got, tt.want)
+			}
+		})
+	}
+}
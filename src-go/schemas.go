@@ -0,0 +1,122 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type GetWorkPackageSchemaArguments struct {
+	ConnectionArguments
+	ProjectID int `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+	TypeID    int `json:"type_id" jsonschema:"required,description=Work package type ID (see list_types),example=1"`
+}
+
+func (a GetWorkPackageSchemaArguments) validate() error {
+	return errors.Join(
+		positiveID("project_id", a.ProjectID),
+		positiveID("type_id", a.TypeID),
+	)
+}
+
+// SchemaPropertyResponse describes one attribute of a work package schema
+type SchemaPropertyResponse struct {
+	Type       string `json:"type"`
+	Name       string `json:"name"`
+	Required   bool   `json:"required"`
+	HasDefault bool   `json:"hasDefault"`
+	Writable   bool   `json:"writable"`
+	Links      struct {
+		// an array of links, or a single link to a collection for large value sets
+		AllowedValues json.RawMessage `json:"allowedValues"`
+	} `json:"_links"`
+	Embedded struct {
+		AllowedValues []struct {
+			ID   int    `json:"id"`
+			Name string `json:"name"`
+		} `json:"allowedValues"`
+	} `json:"_embedded"`
+}
+
+type SchemaAttribute struct {
+	Attribute string `json:"attribute"`
+	Name      string `json:"name"`
+	Type      string `json:"type"`
+}
+
+type AllowedValue struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+type WorkPackageSchema struct {
+	// writable attributes without a default that a new work package must set
+	Required []SchemaAttribute `json:"required"`
+	Writable []string          `json:"writable"`
+	// for status, priority and type
+	AllowedValues map[string][]AllowedValue `json:"allowedValues,omitempty"`
+}
+
+// schemaAllowedValues are the attributes whose allowed values are returned
+var schemaAllowedValues = []string{"status", "priority", "type"}
+
+func (c *openProjectClient) getWorkPackageSchema(ctx context.Context, arguments GetWorkPackageSchemaArguments) (*mcp_golang.ToolResponse, error) {
+	var properties map[string]json.RawMessage
+	path := fmt.Sprintf("/api/v3/work_packages/schemas/%d-%d", arguments.ProjectID, arguments.TypeID)
+	if err := c.getCached(ctx, path, &properties); err != nil {
+		return nil, err
+	}
+
+	schema := WorkPackageSchema{Required: []SchemaAttribute{}, Writable: []string{}, AllowedValues: map[string][]AllowedValue{}}
+	for attribute, raw := range properties {
+		// _type, _links, _dependencies, ... are not attributes
+		if strings.HasPrefix(attribute, "_") {
+			continue
+		}
+		var property SchemaPropertyResponse
+		if err := json.Unmarshal(raw, &property); err != nil {
+			continue
+		}
+		if !property.Writable {
+			continue
+		}
+		schema.Writable = append(schema.Writable, attribute)
+		if property.Required && !property.HasDefault {
+			schema.Required = append(schema.Required, SchemaAttribute{Attribute: attribute, Name: property.Name, Type: property.Type})
+		}
+	}
+	for _, attribute := range schemaAllowedValues {
+		var property SchemaPropertyResponse
+		if err := json.Unmarshal(properties[attribute], &property); err != nil {
+			continue
+		}
+		values := make([]AllowedValue, 0, len(property.Embedded.AllowedValues))
+		for _, v := range property.Embedded.AllowedValues {
+			values = append(values, AllowedValue{ID: v.ID, Name: v.Name})
+		}
+		var links []Link
+		var collection Link
+		switch {
+		case len(values) > 0:
+		case json.Unmarshal(property.Links.AllowedValues, &links) == nil:
+			for _, l := range links {
+				values = append(values, AllowedValue{ID: l.ID(), Name: l.Title})
+			}
+		case json.Unmarshal(property.Links.AllowedValues, &collection) == nil && collection.Href != "":
+			elements, err := fetchAll[AllowedValue](ctx, c, c.hrefPath(collection.Href))
+			if err != nil {
+				return nil, err
+			}
+			values = append(values, elements...)
+		}
+		schema.AllowedValues[attribute] = values
+	}
+	sort.Strings(schema.Writable)
+	sort.Slice(schema.Required, func(i, j int) bool { return schema.Required[i].Attribute < schema.Required[j].Attribute })
+	return jsonResponse(schema)
+}
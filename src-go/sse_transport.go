@@ -0,0 +1,315 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+
+	"github.com/metoro-io/mcp-golang/transport"
+)
+
+// maximum size of a single JSON-RPC message POSTed by a client
+const sseMaxMessageSize = 4 * 1024 * 1024
+
+// sseTransport implements transport.Transport over HTTP + Server-Sent Events.
+//
+// A client opens GET /sse and receives an "endpoint" event with the URL to POST
+// its JSON-RPC messages to; responses are streamed back as "message" events.
+// Several clients can be connected at once: request IDs are rewritten to be
+// unique across sessions and restored when the response is sent. When a token
+// is set, both endpoints require it as an "Authorization: Bearer" header.
+type sseTransport struct {
+	addr   string
+	token  string
+	server *http.Server
+	// extra endpoints served next to /sse and /message, e.g. /metrics
+	handlers map[string]http.Handler
+	// closed on shutdown so open event streams return
+	closing chan struct{}
+
+	mu        sync.Mutex
+	sessions  map[string]*sseSession
+	pending   map[transport.RequestId]pendingRequest
+	nextID    transport.RequestId
+	onClose   func()
+	onError   func(error)
+	onMessage func(ctx context.Context, message *transport.BaseJsonRpcMessage)
+}
+
+type sseSession struct {
+	id     string
+	events chan []byte
+	done   chan struct{}
+}
+
+type pendingRequest struct {
+	session    *sseSession
+	originalID transport.RequestId
+}
+
+func newSSETransport(addr, token string) *sseTransport {
+	return &sseTransport{
+		addr:     addr,
+		token:    token,
+		handlers: make(map[string]http.Handler),
+		closing:  make(chan struct{}),
+		sessions: make(map[string]*sseSession),
+		pending:  make(map[transport.RequestId]pendingRequest),
+	}
+}
+
+// Start listens on the configured address and serves in the background
+func (t *sseTransport) Start(ctx context.Context) error {
+	mux := http.NewServeMux()
+	mux.HandleFunc("/sse", t.handleStream)
+	mux.HandleFunc("/message", t.handleMessage)
+	for pattern, handler := range t.handlers {
+		mux.Handle(pattern, handler)
+	}
+	t.server = &http.Server{Addr: t.addr, Handler: mux}
+
+	listener, err := net.Listen("tcp", t.addr)
+	if err != nil {
+		return err
+	}
+	go func() {
+		if err := t.server.Serve(listener); err != nil && err != http.ErrServerClosed {
+			t.handleError(err)
+		}
+	}()
+	return nil
+}
+
+// Handle serves an additional endpoint; it must be called before Start
+func (t *sseTransport) Handle(pattern string, handler http.Handler) {
+	t.handlers[pattern] = handler
+}
+
+// Send routes a response back to the session that issued the request;
+// server-initiated messages (notifications) are broadcast to every session
+func (t *sseTransport) Send(ctx context.Context, message *transport.BaseJsonRpcMessage) error {
+	t.mu.Lock()
+	var targets []*sseSession
+	switch {
+	case message.JsonRpcResponse != nil:
+		if p, ok := t.pending[message.JsonRpcResponse.Id]; ok {
+			delete(t.pending, message.JsonRpcResponse.Id)
+			message.JsonRpcResponse.Id = p.originalID
+			targets = append(targets, p.session)
+		}
+	case message.JsonRpcError != nil:
+		if p, ok := t.pending[message.JsonRpcError.Id]; ok {
+			delete(t.pending, message.JsonRpcError.Id)
+			message.JsonRpcError.Id = p.originalID
+			targets = append(targets, p.session)
+		}
+	default:
+		for _, session := range t.sessions {
+			targets = append(targets, session)
+		}
+	}
+	t.mu.Unlock()
+
+	data, err := json.Marshal(message)
+	if err != nil {
+		return fmt.Errorf("failed to marshal message: %w", err)
+	}
+	for _, session := range targets {
+		select {
+		case session.events <- data:
+		case <-session.done:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	return nil
+}
+
+func (t *sseTransport) Close() error {
+	var err error
+	if t.server != nil {
+		err = t.server.Close()
+	}
+	t.mu.Lock()
+	handler := t.onClose
+	t.mu.Unlock()
+	if handler != nil {
+		handler()
+	}
+	return err
+}
+
+// Shutdown ends open event streams and gracefully stops the HTTP server
+func (t *sseTransport) Shutdown(ctx context.Context) error {
+	if t.server == nil {
+		return nil
+	}
+	close(t.closing)
+	return t.server.Shutdown(ctx)
+}
+
+func (t *sseTransport) SetCloseHandler(handler func()) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onClose = handler
+}
+
+func (t *sseTransport) SetErrorHandler(handler func(error)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onError = handler
+}
+
+func (t *sseTransport) SetMessageHandler(handler func(ctx context.Context, message *transport.BaseJsonRpcMessage)) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	t.onMessage = handler
+}
+
+func (t *sseTransport) handleError(err error) {
+	t.mu.Lock()
+	handler := t.onError
+	t.mu.Unlock()
+	if handler != nil {
+		handler(err)
+	}
+}
+
+// authorize rejects requests without the configured bearer token
+func (t *sseTransport) authorize(w http.ResponseWriter, r *http.Request) bool {
+	if t.token == "" {
+		return true
+	}
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(t.token)) != 1 {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return false
+	}
+	return true
+}
+
+func (t *sseTransport) handleStream(w http.ResponseWriter, r *http.Request) {
+	if !t.authorize(w, r) {
+		return
+	}
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		http.Error(w, "streaming unsupported", http.StatusInternalServerError)
+		return
+	}
+
+	session := &sseSession{id: newSessionID(), events: make(chan []byte, 16), done: make(chan struct{})}
+	t.mu.Lock()
+	t.sessions[session.id] = session
+	t.mu.Unlock()
+	defer func() {
+		t.mu.Lock()
+		delete(t.sessions, session.id)
+		for id, p := range t.pending {
+			if p.session == session {
+				delete(t.pending, id)
+			}
+		}
+		t.mu.Unlock()
+		close(session.done)
+	}()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	fmt.Fprintf(w, "event: endpoint\ndata: /message?sessionId=%s\n\n", session.id)
+	flusher.Flush()
+
+	for {
+		select {
+		case data := <-session.events:
+			fmt.Fprintf(w, "event: message\ndata: %s\n\n", data)
+			flusher.Flush()
+		case <-r.Context().Done():
+			return
+		case <-t.closing:
+			return
+		}
+	}
+}
+
+func (t *sseTransport) handleMessage(w http.ResponseWriter, r *http.Request) {
+	if !t.authorize(w, r) {
+		return
+	}
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	t.mu.Lock()
+	session := t.sessions[r.URL.Query().Get("sessionId")]
+	handler := t.onMessage
+	t.mu.Unlock()
+	if session == nil {
+		http.Error(w, "unknown session", http.StatusNotFound)
+		return
+	}
+
+	body, err := io.ReadAll(io.LimitReader(r.Body, sseMaxMessageSize))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	message, err := decodeMessage(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if message.JsonRpcRequest != nil {
+		t.mu.Lock()
+		t.nextID++
+		t.pending[t.nextID] = pendingRequest{session: session, originalID: message.JsonRpcRequest.Id}
+		message.JsonRpcRequest.Id = t.nextID
+		t.mu.Unlock()
+	}
+
+	w.WriteHeader(http.StatusAccepted)
+	if handler != nil {
+		handler(context.Background(), message)
+	}
+}
+
+// decodeMessage classifies a raw JSON-RPC message the same way the stdio transport does
+func decodeMessage(body []byte) (*transport.BaseJsonRpcMessage, error) {
+	var request transport.BaseJSONRPCRequest
+	if err := json.Unmarshal(body, &request); err == nil {
+		return transport.NewBaseMessageRequest(&request), nil
+	}
+	var notification transport.BaseJSONRPCNotification
+	if err := json.Unmarshal(body, &notification); err == nil {
+		return transport.NewBaseMessageNotification(&notification), nil
+	}
+	var response transport.BaseJSONRPCResponse
+	if err := json.Unmarshal(body, &response); err == nil {
+		return transport.NewBaseMessageResponse(&response), nil
+	}
+	var errorResponse transport.BaseJSONRPCError
+	if err := json.Unmarshal(body, &errorResponse); err == nil {
+		return transport.NewBaseMessageError(&errorResponse), nil
+	}
+	return nil, fmt.Errorf("invalid JSON-RPC message")
+}
+
+func newSessionID() string {
+	b := make([]byte, 16)
+	rand.Read(b)
+	return hex.EncodeToString(b)
+}
@@ -0,0 +1,75 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"sort"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ProjectStatusSummaryArguments struct {
+	ConnectionArguments
+	ProjectID int `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+}
+
+func (a ProjectStatusSummaryArguments) validate() error {
+	return positiveID("project_id", a.ProjectID)
+}
+
+type StatusCount struct {
+	Status   string `json:"status"`
+	IsClosed bool   `json:"isClosed"`
+	Count    int    `json:"count"`
+}
+
+type ProjectStatusSummary struct {
+	ProjectID int           `json:"projectId"`
+	Total     int           `json:"total"`
+	Open      int           `json:"open"`
+	Closed    int           `json:"closed"`
+	ByStatus  []StatusCount `json:"byStatus"`
+}
+
+// projectStatusSummary counts every work package of a project (open and closed)
+// by status, so only the counts are returned however large the project is
+func (c *openProjectClient) projectStatusSummary(ctx context.Context, arguments ProjectStatusSummaryArguments) (*mcp_golang.ToolResponse, error) {
+	statuses, err := fetchAll[StatusResponse](ctx, c, "/api/v3/statuses")
+	if err != nil {
+		return nil, err
+	}
+	closed := make(map[string]bool, len(statuses))
+	for _, s := range statuses {
+		closed[s.Name] = s.IsClosed
+	}
+
+	// without an explicit empty filter OpenProject only returns open work packages
+	path := fmt.Sprintf("/api/v3/projects/%d/work_packages?filters=%s&pageSize=100", arguments.ProjectID, url.QueryEscape("[]"))
+	elements, err := fetchAll[WorkPackageResponse](ctx, c, path)
+	if err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	summary := ProjectStatusSummary{ProjectID: arguments.ProjectID, Total: len(elements)}
+	for _, wpResp := range elements {
+		status := wpResp.Links.Status.Title
+		counts[status]++
+		if closed[status] {
+			summary.Closed++
+		} else {
+			summary.Open++
+		}
+	}
+	summary.ByStatus = make([]StatusCount, 0, len(counts))
+	for status, count := range counts {
+		summary.ByStatus = append(summary.ByStatus, StatusCount{Status: status, IsClosed: closed[status], Count: count})
+	}
+	sort.Slice(summary.ByStatus, func(i, j int) bool {
+		if summary.ByStatus[i].Count != summary.ByStatus[j].Count {
+			return summary.ByStatus[i].Count > summary.ByStatus[j].Count
+		}
+		return summary.ByStatus[i].Status < summary.ByStatus[j].Status
+	})
+	return jsonResponse(summary)
+}
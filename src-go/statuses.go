@@ -0,0 +1,55 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListStatusesArguments struct {
+	ConnectionArguments
+}
+
+type StatusResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsClosed  bool   `json:"isClosed"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+type Status struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	IsClosed  bool   `json:"isClosed"`
+	IsDefault bool   `json:"isDefault"`
+}
+
+func (c *openProjectClient) listStatuses(ctx context.Context, arguments ListStatusesArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[StatusResponse](ctx, c, "/api/v3/statuses")
+	if err != nil {
+		return nil, err
+	}
+	statuses := make([]Status, 0, len(elements))
+	for _, s := range elements {
+		statuses = append(statuses, Status{ID: s.ID, Name: s.Name, IsClosed: s.IsClosed, IsDefault: s.IsDefault})
+	}
+	return jsonResponse(statuses)
+}
+
+// resolveStatusID looks up a status by its (case-insensitive) name
+func (c *openProjectClient) resolveStatusID(ctx context.Context, name string) (int, error) {
+	elements, err := fetchAll[StatusResponse](ctx, c, "/api/v3/statuses")
+	if err != nil {
+		return 0, err
+	}
+	names := make([]string, 0, len(elements))
+	for _, s := range elements {
+		if strings.EqualFold(s.Name, strings.TrimSpace(name)) {
+			return s.ID, nil
+		}
+		names = append(names, s.Name)
+	}
+	return 0, fmt.Errorf("unknown status %q, available statuses: %s", name, strings.Join(names, ", "))
+}
@@ -0,0 +1,198 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type LogTimeArguments struct {
+	ConnectionArguments
+	WorkPackageID int     `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	Hours         float64 `json:"hours" jsonschema:"required,description=Time spent in decimal hours,example=2.5,minimum=0"`
+	ActivityID    int     `json:"activity_id" jsonschema:"required,description=Time entry activity ID such as Development (see list_time_entry_activities)"`
+	Comment       string  `json:"comment,omitempty" jsonschema:"description=Comment for the time entry in markdown"`
+	SpentOn       string  `json:"spent_on,omitempty" jsonschema:"description=Date the time was spent as YYYY-MM-DD (default today),example=2024-05-01"`
+}
+
+func (a LogTimeArguments) validate() error {
+	errs := []error{positiveID("work_package_id", a.WorkPackageID)}
+	if a.Hours <= 0 {
+		errs = append(errs, fmt.Errorf("hours must be positive"))
+	} else if durationMinutes(a.Hours) == 0 {
+		// hours are sent rounded to the minute, this would log a zero-length entry
+		errs = append(errs, fmt.Errorf("hours must be at least one minute (0.0167), got %g", a.Hours))
+	}
+	errs = append(errs, positiveID("activity_id", a.ActivityID))
+	if a.SpentOn != "" {
+		if _, err := time.Parse(time.DateOnly, a.SpentOn); err != nil {
+			errs = append(errs, fmt.Errorf("spent_on must be an ISO date (YYYY-MM-DD): %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type TimeEntryPayload struct {
+	Hours   string           `json:"hours"`
+	SpentOn string           `json:"spentOn"`
+	Comment *Formattable     `json:"comment,omitempty"`
+	Links   map[string]*Link `json:"_links"`
+}
+
+type ListTimeEntriesArguments struct {
+	ConnectionArguments
+	WorkPackageID int `json:"work_package_id,omitempty" jsonschema:"description=Only entries logged on this Work Package ID"`
+	UserID        int `json:"user_id,omitempty" jsonschema:"description=Only entries logged by this user ID (see list_users)"`
+}
+
+func (a ListTimeEntriesArguments) validate() error {
+	return errors.Join(
+		optionalID("work_package_id", a.WorkPackageID),
+		optionalID("user_id", a.UserID),
+	)
+}
+
+type ListTimeEntryActivitiesArguments struct {
+	ConnectionArguments
+	WorkPackageID int `json:"work_package_id,omitempty" jsonschema:"description=Work Package ID the time would be logged on; activities can differ per project"`
+}
+
+func (a ListTimeEntryActivitiesArguments) validate() error {
+	return optionalID("work_package_id", a.WorkPackageID)
+}
+
+type TimeEntryFormResponse struct {
+	Embedded struct {
+		Schema struct {
+			Activity SchemaPropertyResponse `json:"activity"`
+		} `json:"schema"`
+	} `json:"_embedded"`
+}
+
+type TimeEntryResponse struct {
+	ID      int         `json:"id"`
+	Hours   string      `json:"hours"`
+	SpentOn string      `json:"spentOn"`
+	Comment Formattable `json:"comment"`
+	Links   struct {
+		WorkPackage Link `json:"workPackage"`
+		User        Link `json:"user"`
+		Activity    Link `json:"activity"`
+	} `json:"_links"`
+}
+
+type TimeEntry struct {
+	ID            int     `json:"id"`
+	Hours         float64 `json:"hours"`
+	SpentOn       string  `json:"spentOn"`
+	Activity      string  `json:"activity"`
+	User          string  `json:"user"`
+	WorkPackageID int     `json:"workPackageId,omitempty"`
+	Comment       string  `json:"comment,omitempty"`
+}
+
+type TimeEntryList struct {
+	TotalHours  float64     `json:"totalHours"`
+	TimeEntries []TimeEntry `json:"timeEntries"`
+}
+
+func (c *openProjectClient) logTime(ctx context.Context, arguments LogTimeArguments) (*mcp_golang.ToolResponse, error) {
+	spentOn := arguments.SpentOn
+	if spentOn == "" {
+		spentOn = time.Now().Format(time.DateOnly)
+	}
+
+	payload := TimeEntryPayload{
+		// OpenProject rejects plain decimal hours
+		Hours:   formatISODuration(arguments.Hours),
+		SpentOn: spentOn,
+		Links: map[string]*Link{
+			"workPackage": {Href: fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID)},
+			"activity":    {Href: fmt.Sprintf("/api/v3/time_entries/activities/%d", arguments.ActivityID)},
+		},
+	}
+	if arguments.Comment != "" {
+		payload.Comment = &Formattable{Raw: arguments.Comment}
+	}
+
+	var entry TimeEntryResponse
+	if err := c.do(ctx, "POST", "/api/v3/time_entries", payload, &entry); err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID int `json:"id"`
+	}{entry.ID})
+}
+
+func (c *openProjectClient) listTimeEntries(ctx context.Context, arguments ListTimeEntriesArguments) (*mcp_golang.ToolResponse, error) {
+	filters := []Filter{}
+	if arguments.WorkPackageID != 0 {
+		filters = append(filters, newFilter("work_package", "=", strconv.Itoa(arguments.WorkPackageID)))
+	}
+	if arguments.UserID != 0 {
+		filters = append(filters, newFilter("user", "=", strconv.Itoa(arguments.UserID)))
+	}
+	query, err := encodeFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := fetchAll[TimeEntryResponse](ctx, c, "/api/v3/time_entries?filters="+query)
+	if err != nil {
+		return nil, err
+	}
+
+	list := TimeEntryList{TimeEntries: make([]TimeEntry, 0, len(elements))}
+	for _, e := range elements {
+		hours, err := parseISODuration(e.Hours)
+		if err != nil {
+			return nil, err
+		}
+		list.TotalHours += hours
+		list.TimeEntries = append(list.TimeEntries, TimeEntry{
+			ID:            e.ID,
+			Hours:         hours,
+			SpentOn:       e.SpentOn,
+			Activity:      e.Links.Activity.Title,
+			User:          e.Links.User.Title,
+			WorkPackageID: e.Links.WorkPackage.ID(),
+			Comment:       e.Comment.Raw,
+		})
+	}
+	return jsonResponse(list)
+}
+
+// listTimeEntryActivities reads the allowed activities from the time entry form,
+// since API v3 has no collection of activities
+func (c *openProjectClient) listTimeEntryActivities(ctx context.Context, arguments ListTimeEntryActivitiesArguments) (*mcp_golang.ToolResponse, error) {
+	payload := struct {
+		Links map[string]*Link `json:"_links"`
+	}{map[string]*Link{}}
+	if arguments.WorkPackageID != 0 {
+		payload.Links["workPackage"] = &Link{Href: fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID)}
+	}
+	var form TimeEntryFormResponse
+	// validating a form changes nothing, so it is sent even with --dry-run
+	readOnly := *c
+	readOnly.dryRun = false
+	if err := readOnly.do(ctx, "POST", "/api/v3/time_entries/form", payload, &form); err != nil {
+		return nil, err
+	}
+
+	activity := form.Embedded.Schema.Activity
+	activities := make([]AllowedValue, 0, len(activity.Embedded.AllowedValues))
+	for _, a := range activity.Embedded.AllowedValues {
+		activities = append(activities, AllowedValue{ID: a.ID, Name: a.Name})
+	}
+	var links []Link
+	if len(activities) == 0 && json.Unmarshal(activity.Links.AllowedValues, &links) == nil {
+		for _, l := range links {
+			activities = append(activities, AllowedValue{ID: l.ID(), Name: l.Title})
+		}
+	}
+	return jsonResponse(activities)
+}
@@ -0,0 +1,180 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// ConnectionArguments holds options shared by every tool; it is embedded in each arguments struct
+type ConnectionArguments struct {
+	ApiKey   string `json:"api_key,omitempty" jsonschema:"description=OpenProject API key to use for this call instead of the server's default key"`
+	Instance string `json:"instance,omitempty" jsonschema:"description=Name of the OpenProject instance from the config file to call (default the --baseurl instance)"`
+	Pretty   bool   `json:"pretty,omitempty" jsonschema:"description=Indent the JSON output for reading in a terminal (default compact)"`
+}
+
+func (a ConnectionArguments) connection() ConnectionArguments {
+	return a
+}
+
+type toolArguments interface {
+	connection() ConnectionArguments
+}
+
+// validator is implemented by arguments that can be checked before any request is made
+type validator interface {
+	validate() error
+}
+
+func positiveID(name string, id int) error {
+	if id <= 0 {
+		return fmt.Errorf("%s must be a positive integer", name)
+	}
+	return nil
+}
+
+// optionalID accepts 0, which tools use for "not given" or "clear"
+func optionalID(name string, id int) error {
+	if id < 0 {
+		return fmt.Errorf("%s must not be negative", name)
+	}
+	return nil
+}
+
+// toolHandler is a tool implemented as an openProjectClient method, e.g. (*openProjectClient).getWorkPackage
+type toolHandler[T toolArguments] func(c *openProjectClient, ctx context.Context, arguments T) (*mcp_golang.ToolResponse, error)
+
+// boundTool builds the function registered with the MCP server under name
+type boundTool func(name string) any
+
+// bind turns handler into the function registered with the MCP server,
+// selecting the client to call it on from the shared connection arguments
+func bind[T toolArguments](client *openProjectClient, handler toolHandler[T]) boundTool {
+	return func(name string) any {
+		return func(ctx context.Context, arguments T) (*mcp_golang.ToolResponse, error) {
+			client.metrics.toolCalled(name)
+			ctx, correlationID := withCall(ctx, name)
+			if v, ok := any(arguments).(validator); ok {
+				if err := v.validate(); err != nil {
+					return nil, err
+				}
+			}
+			conn, err := client.forConnection(arguments.connection())
+			if err != nil {
+				return nil, err
+			}
+			response, err := handler(conn, ctx, arguments)
+			var dryRun *dryRunRequest
+			if errors.As(err, &dryRun) {
+				response, err = jsonResponse(struct {
+					DryRun bool `json:"dryRun"`
+					*dryRunRequest
+				}{true, dryRun})
+			}
+			if err != nil && client.verbose {
+				err = fmt.Errorf("%w (correlation id %s)", err, correlationID)
+			}
+			if err == nil && arguments.connection().Pretty {
+				indentResponse(response)
+			}
+			return response, err
+		}
+	}
+}
+
+// forConnection returns a client for the named instance that authenticates with
+// the per-call API key, if one was given
+func (c *openProjectClient) forConnection(conn ConnectionArguments) (*openProjectClient, error) {
+	if conn.ApiKey == "" && conn.Instance == "" {
+		return c, nil
+	}
+	override := *c
+	if conn.Instance != "" {
+		instance, ok := c.instances[conn.Instance]
+		if !ok {
+			return nil, fmt.Errorf("unknown instance %q", conn.Instance)
+		}
+		override.baseURL = instance.baseURL
+		override.basicAuth = instance.basicAuth
+		// OAuth2 tokens are issued for the default instance only
+		override.httpClient = withoutCredentials(c.httpClient)
+	}
+	if conn.ApiKey != "" {
+		override.basicAuth = basicAuthHeader(conn.ApiKey)
+		// the explicit key replaces an OAuth2 bearer token, if one is configured
+		override.httpClient = withoutCredentials(c.httpClient)
+	}
+	return &override, nil
+}
+
+// writeTools create, change or delete data in OpenProject. They are registered
+// only when named in --enable-tools, or with --enable-tools=all, so that by
+// default an agent cannot modify anything.
+var writeTools = map[string]bool{
+	"create_work_package":        true,
+	"copy_work_package":          true,
+	"edit_work_package":          true,
+	"update_work_package_status": true,
+	"bulk_update_status":         true,
+	"comment_work_package":       true,
+	"log_time":                   true,
+	"assign_work_package":        true,
+	"add_membership":             true,
+	"set_progress":               true,
+	"set_parent":                 true,
+	"delete_work_package":        true,
+	"create_relation":            true,
+	"delete_relation":            true,
+	"upload_attachment":          true,
+	"set_priority":               true,
+	"create_version":             true,
+	"set_version":                true,
+	"set_category":               true,
+	"set_custom_field":           true,
+	"add_watcher":                true,
+	"remove_watcher":             true,
+}
+
+// toolFilter selects the registered tools from --enable-tools and --disable-tools
+type toolFilter struct {
+	enabled  map[string]bool
+	disabled map[string]bool
+	allWrite bool
+}
+
+// newToolFilter parses the comma-separated flag values, rejecting names that
+// are not in known so that a typo does not silently expose or hide a tool
+func newToolFilter(enable, disable string, known map[string]bool) (*toolFilter, error) {
+	f := &toolFilter{enabled: map[string]bool{}, disabled: map[string]bool{}}
+	for _, list := range []struct {
+		flag  string
+		value string
+		names map[string]bool
+	}{{"enable-tools", enable, f.enabled}, {"disable-tools", disable, f.disabled}} {
+		for _, name := range strings.Split(list.value, ",") {
+			name = strings.TrimSpace(name)
+			switch {
+			case name == "":
+				continue
+			case name == "all" && list.flag == "enable-tools":
+				f.allWrite = true
+			case !known[name]:
+				return nil, fmt.Errorf("--%s: unknown tool %q", list.flag, name)
+			default:
+				list.names[name] = true
+			}
+		}
+	}
+	return f, nil
+}
+
+// allowed reports whether a tool is registered; --disable-tools wins over --enable-tools
+func (f *toolFilter) allowed(name string) bool {
+	if f.disabled[name] {
+		return false
+	}
+	return !writeTools[name] || f.allWrite || f.enabled[name]
+}
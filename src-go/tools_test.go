@@ -0,0 +1,86 @@
+package main
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestToolFilter(t *testing.T) {
+	known := map[string]bool{"list_projects": true, "create_work_package": true, "delete_work_package": true}
+	tests := []struct {
+		name         string
+		enable       string
+		disable      string
+		wantAllowed  []string
+		wantDisabled []string
+	}{
+		{
+			name:         "write tools off by default",
+			wantAllowed:  []string{"list_projects"},
+			wantDisabled: []string{"create_work_package", "delete_work_package"},
+		},
+		{
+			name:        "all",
+			enable:      "all",
+			wantAllowed: []string{"list_projects", "create_work_package", "delete_work_package"},
+		},
+		{
+			name:         "single write tool",
+			enable:       " create_work_package ",
+			wantAllowed:  []string{"list_projects", "create_work_package"},
+			wantDisabled: []string{"delete_work_package"},
+		},
+		{
+			name:         "disable wins over enable",
+			enable:       "all",
+			disable:      "delete_work_package,list_projects",
+			wantAllowed:  []string{"create_work_package"},
+			wantDisabled: []string{"delete_work_package", "list_projects"},
+		},
+		{
+			name:         "disable wins over a named write tool",
+			enable:       "create_work_package",
+			disable:      "create_work_package",
+			wantAllowed:  []string{"list_projects"},
+			wantDisabled: []string{"create_work_package", "delete_work_package"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			f, err := newToolFilter(tt.enable, tt.disable, known)
+			if err != nil {
+				t.Fatal(err)
+			}
+			for _, name := range tt.wantAllowed {
+				if !f.allowed(name) {
+					t.Errorf("%s is not allowed", name)
+				}
+			}
+			for _, name := range tt.wantDisabled {
+				if f.allowed(name) {
+					t.Errorf("%s is allowed", name)
+				}
+			}
+		})
+	}
+}
+
+func TestToolFilterUnknownName(t *testing.T) {
+	known := map[string]bool{"list_projects": true, "create_work_package": true}
+	tests := []struct {
+		enable  string
+		disable string
+		want    string
+	}{
+		{enable: "create_work_pakage", want: `--enable-tools: unknown tool "create_work_pakage"`},
+		{disable: "list_projects,typo", want: `--disable-tools: unknown tool "typo"`},
+		// all only makes sense for enabling
+		{disable: "all", want: `--disable-tools: unknown tool "all"`},
+	}
+	for _, tt := range tests {
+		_, err := newToolFilter(tt.enable, tt.disable, known)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("newToolFilter(%q, %q): got error %v, want %q", tt.enable, tt.disable, err, tt.want)
+		}
+	}
+}
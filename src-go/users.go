@@ -0,0 +1,70 @@
+package main
+
+import (
+	"context"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListUsersArguments struct {
+	ConnectionArguments
+	NameFilter string `json:"name_filter,omitempty" jsonschema:"description=Only return users whose name contains this text,example=alice"`
+}
+
+type GetCurrentUserArguments struct {
+	ConnectionArguments
+}
+
+type UserResponse struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Login    string `json:"login"`
+	Email    string `json:"email"`
+	Admin    bool   `json:"admin"`
+	Language string `json:"language"`
+}
+
+type User struct {
+	ID    int    `json:"id"`
+	Name  string `json:"name"`
+	Login string `json:"login"`
+	Email string `json:"email,omitempty"`
+}
+
+type CurrentUser struct {
+	ID       int    `json:"id"`
+	Name     string `json:"name"`
+	Login    string `json:"login"`
+	Email    string `json:"email,omitempty"`
+	Admin    bool   `json:"admin"`
+	Language string `json:"language,omitempty"`
+}
+
+func (c *openProjectClient) getCurrentUser(ctx context.Context, arguments GetCurrentUserArguments) (*mcp_golang.ToolResponse, error) {
+	var me UserResponse
+	if err := c.get(ctx, "/api/v3/users/me", &me); err != nil {
+		return nil, err
+	}
+	return jsonResponse(CurrentUser{ID: me.ID, Name: me.Name, Login: me.Login, Email: me.Email, Admin: me.Admin, Language: me.Language})
+}
+
+func (c *openProjectClient) listUsers(ctx context.Context, arguments ListUsersArguments) (*mcp_golang.ToolResponse, error) {
+	path := "/api/v3/users"
+	if arguments.NameFilter != "" {
+		query, err := encodeFilters([]Filter{newFilter("name", "~", arguments.NameFilter)})
+		if err != nil {
+			return nil, err
+		}
+		path += "?filters=" + query
+	}
+
+	elements, err := fetchAll[UserResponse](ctx, c, path)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]User, 0, len(elements))
+	for _, u := range elements {
+		users = append(users, User{ID: u.ID, Name: u.Name, Login: u.Login, Email: u.Email})
+	}
+	return jsonResponse(users)
+}
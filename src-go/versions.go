@@ -0,0 +1,127 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListVersionsArguments struct {
+	ConnectionArguments
+	ProjectID int `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+}
+
+func (a ListVersionsArguments) validate() error {
+	return positiveID("project_id", a.ProjectID)
+}
+
+type SetVersionArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	VersionID     int `json:"version_id" jsonschema:"required,description=ID of the target version (see list_versions)\\, or 0 to clear it"`
+}
+
+func (a SetVersionArguments) validate() error {
+	return errors.Join(
+		positiveID("work_package_id", a.WorkPackageID),
+		optionalID("version_id", a.VersionID),
+	)
+}
+
+type CreateVersionArguments struct {
+	ConnectionArguments
+	ProjectID   int    `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+	Name        string `json:"name" jsonschema:"required,description=Name of the new version,example=1.2.0"`
+	Description string `json:"description,omitempty" jsonschema:"description=Description in markdown"`
+	DueDate     string `json:"due_date,omitempty" jsonschema:"description=Finish date as YYYY-MM-DD,example=2024-06-30"`
+}
+
+func (a CreateVersionArguments) validate() error {
+	var errs []error
+	if strings.TrimSpace(a.Name) == "" {
+		errs = append(errs, fmt.Errorf("name must not be empty"))
+	}
+	if a.DueDate != "" {
+		if _, err := time.Parse(time.DateOnly, a.DueDate); err != nil {
+			errs = append(errs, fmt.Errorf("due_date must be an ISO date (YYYY-MM-DD): %w", err))
+		}
+	}
+	return errors.Join(append(errs, positiveID("project_id", a.ProjectID))...)
+}
+
+type VersionPayload struct {
+	Name        string           `json:"name"`
+	Description *Formattable     `json:"description,omitempty"`
+	EndDate     string           `json:"endDate,omitempty"`
+	Links       map[string]*Link `json:"_links"`
+}
+
+type VersionResponse struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	StartDate string `json:"startDate"`
+	EndDate   string `json:"endDate"`
+}
+
+type Version struct {
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	Status    string `json:"status"`
+	StartDate string `json:"startDate,omitempty"`
+	DueDate   string `json:"dueDate,omitempty"`
+}
+
+func (c *openProjectClient) listVersions(ctx context.Context, arguments ListVersionsArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[VersionResponse](ctx, c, fmt.Sprintf("/api/v3/projects/%d/versions", arguments.ProjectID))
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]Version, 0, len(elements))
+	for _, v := range elements {
+		versions = append(versions, Version{ID: v.ID, Name: v.Name, Status: v.Status, StartDate: v.StartDate, DueDate: v.EndDate})
+	}
+	return jsonResponse(versions)
+}
+
+func (c *openProjectClient) createVersion(ctx context.Context, arguments CreateVersionArguments) (*mcp_golang.ToolResponse, error) {
+	payload := VersionPayload{
+		Name:    arguments.Name,
+		EndDate: arguments.DueDate,
+		Links: map[string]*Link{
+			"definingProject": {Href: fmt.Sprintf("/api/v3/projects/%d", arguments.ProjectID)},
+		},
+	}
+	if arguments.Description != "" {
+		payload.Description = &Formattable{Raw: arguments.Description}
+	}
+
+	var created VersionResponse
+	if err := c.do(ctx, "POST", "/api/v3/versions", payload, &created); err != nil {
+		return nil, err
+	}
+	return jsonResponse(Version{ID: created.ID, Name: created.Name, Status: created.Status, StartDate: created.StartDate, DueDate: created.EndDate})
+}
+
+func (c *openProjectClient) setVersion(ctx context.Context, arguments SetVersionArguments) (*mcp_golang.ToolResponse, error) {
+	version := &Link{}
+	if arguments.VersionID != 0 {
+		version.Href = fmt.Sprintf("/api/v3/versions/%d", arguments.VersionID)
+	}
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, WorkPackagePayload{
+		LockVersion: arguments.LockVersion,
+		Links:       map[string]*Link{"version": version},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID      int    `json:"id"`
+		Version string `json:"version"`
+	}{updated.ID, updated.Links.Version.Title})
+}
@@ -0,0 +1,88 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+const (
+	defaultWaitInterval = 30
+	defaultWaitTimeout  = 300
+	maxWaitTimeout      = 3600
+)
+
+type WaitForStatusArguments struct {
+	ConnectionArguments
+	WorkPackageID   int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	TargetStatusID  int `json:"target_status_id" jsonschema:"required,description=Status ID to wait for (see list_statuses),example=7"`
+	IntervalSeconds int `json:"interval_seconds,omitempty" jsonschema:"description=Seconds between two polls (default 30),minimum=1"`
+	TimeoutSeconds  int `json:"timeout_seconds,omitempty" jsonschema:"description=Seconds to wait at most before returning the current state (default 300),minimum=1,maximum=3600"`
+}
+
+func (a WaitForStatusArguments) validate() error {
+	errs := []error{positiveID("work_package_id", a.WorkPackageID), positiveID("target_status_id", a.TargetStatusID)}
+	if a.IntervalSeconds < 0 || a.TimeoutSeconds < 0 {
+		errs = append(errs, fmt.Errorf("interval_seconds and timeout_seconds must not be negative"))
+	}
+	if a.TimeoutSeconds > maxWaitTimeout {
+		errs = append(errs, fmt.Errorf("timeout_seconds must be at most %d", maxWaitTimeout))
+	}
+	return errors.Join(errs...)
+}
+
+// WaitResult is the state of the work package when wait_for_status stopped polling
+type WaitResult struct {
+	Reached     bool                `json:"reached"`
+	Polls       int                 `json:"polls"`
+	WorkPackage *MinimalWorkPackage `json:"workPackage"`
+}
+
+// waitForStatus polls a work package until it has the target status or the
+// timeout elapses. Running out of time is not an error: the last state is
+// returned with reached false. Canceling the call stops the loop.
+func (c *openProjectClient) waitForStatus(ctx context.Context, arguments WaitForStatusArguments) (*mcp_golang.ToolResponse, error) {
+	interval := time.Duration(arguments.IntervalSeconds) * time.Second
+	if interval == 0 {
+		interval = defaultWaitInterval * time.Second
+	}
+	timeout := time.Duration(arguments.TimeoutSeconds) * time.Second
+	if timeout == 0 {
+		timeout = defaultWaitTimeout * time.Second
+	}
+	deadline := time.Now().Add(timeout)
+
+	result := WaitResult{}
+	for {
+		// bypass the cache, the status is expected to change between polls
+		var wpResp WorkPackageResponse
+		if err := c.get(ctx, fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID), &wpResp); err != nil {
+			return nil, err
+		}
+		result.Polls++
+		wp, err := toMinimalWorkPackage(&wpResp, CurlToolArguments{})
+		if err != nil {
+			return nil, err
+		}
+		result.WorkPackage = wp
+		if wpResp.Links.Status.ID() == arguments.TargetStatusID {
+			result.Reached = true
+			return jsonResponse(result)
+		}
+
+		remaining := time.Until(deadline)
+		if remaining <= 0 {
+			return jsonResponse(result)
+		}
+		timer := time.NewTimer(min(interval, remaining))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, fmt.Errorf("stopped waiting for status %d of work package %d: %w", arguments.TargetStatusID, arguments.WorkPackageID, ctx.Err())
+		case <-timer.C:
+		}
+	}
+}
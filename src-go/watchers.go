@@ -0,0 +1,71 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListWatchersArguments struct {
+	ConnectionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+}
+
+func (a ListWatchersArguments) validate() error {
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+type WatcherArguments struct {
+	ConnectionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	UserID        int `json:"user_id" jsonschema:"required,description=ID of the watching user (see list_users)"`
+}
+
+func (a WatcherArguments) validate() error {
+	return errors.Join(
+		positiveID("work_package_id", a.WorkPackageID),
+		positiveID("user_id", a.UserID),
+	)
+}
+
+type Watcher struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+func (c *openProjectClient) listWatchers(ctx context.Context, arguments ListWatchersArguments) (*mcp_golang.ToolResponse, error) {
+	elements, err := fetchAll[UserResponse](ctx, c, fmt.Sprintf("/api/v3/work_packages/%d/watchers", arguments.WorkPackageID))
+	if err != nil {
+		return nil, err
+	}
+	watchers := make([]Watcher, 0, len(elements))
+	for _, u := range elements {
+		watchers = append(watchers, Watcher{ID: u.ID, Name: u.Name})
+	}
+	return jsonResponse(watchers)
+}
+
+func (c *openProjectClient) addWatcher(ctx context.Context, arguments WatcherArguments) (*mcp_golang.ToolResponse, error) {
+	payload := struct {
+		User Link `json:"user"`
+	}{Link{Href: fmt.Sprintf("/api/v3/users/%d", arguments.UserID)}}
+	var user UserResponse
+	if err := c.do(ctx, "POST", fmt.Sprintf("/api/v3/work_packages/%d/watchers", arguments.WorkPackageID), payload, &user); err != nil {
+		return nil, err
+	}
+	return jsonResponse(Watcher{ID: user.ID, Name: user.Name})
+}
+
+func (c *openProjectClient) removeWatcher(ctx context.Context, arguments WatcherArguments) (*mcp_golang.ToolResponse, error) {
+	path := fmt.Sprintf("/api/v3/work_packages/%d/watchers/%d", arguments.WorkPackageID, arguments.UserID)
+	if err := c.do(ctx, "DELETE", path, nil, nil); err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		WorkPackageID int  `json:"workPackageId"`
+		UserID        int  `json:"userId"`
+		Removed       bool `json:"removed"`
+	}{arguments.WorkPackageID, arguments.UserID, true})
+}
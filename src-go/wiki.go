@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type GetWikiPageArguments struct {
+	ConnectionArguments
+	WikiPageID int `json:"wiki_page_id" jsonschema:"required,description=Wiki page ID of OpenProject (the number in a /wiki_pages/ link)"`
+}
+
+func (a GetWikiPageArguments) validate() error {
+	return positiveID("wiki_page_id", a.WikiPageID)
+}
+
+// WikiPageResponse is the API v3 wiki page. API v3 documents only its title,
+// project and attachments, not its text.
+type WikiPageResponse struct {
+	ID    int    `json:"id"`
+	Title string `json:"title"`
+	Links struct {
+		Project Link `json:"project"`
+	} `json:"_links"`
+}
+
+func (w *WikiPageResponse) halType() string { return "WikiPage" }
+
+type WikiPage struct {
+	ID          int          `json:"id"`
+	Title       string       `json:"title"`
+	Project     string       `json:"project,omitempty"`
+	Attachments []Attachment `json:"attachments"`
+}
+
+// getWikiPage reads the metadata and attachments of a single wiki page; files
+// such as runbooks can then be read with download_attachment. API v3 exposes
+// neither the text of a page nor an endpoint listing the pages of a project,
+// so list_wiki_pages is not offered and pages can only be reached by ID (e.g.
+// from links in work package descriptions).
+func (c *openProjectClient) getWikiPage(ctx context.Context, arguments GetWikiPageArguments) (*mcp_golang.ToolResponse, error) {
+	var page WikiPageResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v3/wiki_pages/%d", arguments.WikiPageID), &page); err != nil {
+		return nil, err
+	}
+	elements, err := fetchAll[AttachmentResponse](ctx, c, fmt.Sprintf("/api/v3/wiki_pages/%d/attachments", arguments.WikiPageID))
+	if err != nil {
+		return nil, err
+	}
+	attachments := make([]Attachment, 0, len(elements))
+	for _, a := range elements {
+		attachments = append(attachments, toAttachment(a))
+	}
+	return jsonResponse(WikiPage{ID: page.ID, Title: page.Title, Project: page.Links.Project.Title, Attachments: attachments})
+}
@@ -0,0 +1,45 @@
+package main
+
+import (
+	"context"
+	"fmt"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+type ListTypesArguments struct {
+	ConnectionArguments
+	ProjectID int `json:"project_id,omitempty" jsonschema:"description=Only list types enabled in this project ID (default all types)"`
+}
+
+func (a ListTypesArguments) validate() error {
+	return optionalID("project_id", a.ProjectID)
+}
+
+type TypeResponse struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	IsMilestone bool   `json:"isMilestone"`
+}
+
+type WorkPackageType struct {
+	ID          int    `json:"id"`
+	Name        string `json:"name"`
+	IsMilestone bool   `json:"isMilestone"`
+}
+
+func (c *openProjectClient) listTypes(ctx context.Context, arguments ListTypesArguments) (*mcp_golang.ToolResponse, error) {
+	path := "/api/v3/types"
+	if arguments.ProjectID != 0 {
+		path = fmt.Sprintf("/api/v3/projects/%d/types", arguments.ProjectID)
+	}
+	elements, err := fetchAll[TypeResponse](ctx, c, path)
+	if err != nil {
+		return nil, err
+	}
+	types := make([]WorkPackageType, 0, len(elements))
+	for _, t := range elements {
+		types = append(types, WorkPackageType{ID: t.ID, Name: t.Name, IsMilestone: t.IsMilestone})
+	}
+	return jsonResponse(types)
+}
@@ -0,0 +1,1097 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	mcp_golang "github.com/metoro-io/mcp-golang"
+)
+
+// Add arguments struct for the curl tool
+// No arguments needed for this specific endpoint, but struct is required by framework
+// You can extend this to accept URL, headers, etc. if needed
+type CurlToolArguments struct {
+	ConnectionArguments
+	WorkPackageID     int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	DescriptionFormat string `json:"description_format,omitempty" jsonschema:"enum=raw,enum=html,enum=plain,description=Format of the returned description (default raw)"`
+	CommentFormat     string `json:"comment_format,omitempty" jsonschema:"enum=raw,enum=html,description=Format of the returned comments; html resolves @mentions and work package links to names (default raw)"`
+	OutputFormat      string `json:"output_format,omitempty" jsonschema:"enum=json,enum=markdown,description=Format of the tool output (default json)"`
+	CleanDescription  bool   `json:"clean_description,omitempty" jsonschema:"description=Strip OpenProject macros and embedded images from the description"`
+	Raw               bool   `json:"raw,omitempty" jsonschema:"description=Return the full unmodified OpenProject JSON instead of the minimal projection"`
+	Timezone          string `json:"timezone,omitempty" jsonschema:"description=IANA time zone timestamps are converted to (default as returned by OpenProject),example=Europe/Berlin"`
+	DateFormat        string `json:"date_format,omitempty" jsonschema:"description=Go time layout for dates and timestamps (default ISO 8601),example=2006-01-02 15:04"`
+}
+
+func (a CurlToolArguments) validate() error {
+	_, err := newDateFormat(a.Timezone, a.DateFormat)
+	return errors.Join(positiveID("work_package_id", a.WorkPackageID), err, validCommentFormat(a.CommentFormat))
+}
+
+func (a GetWorkPackageFromURLArguments) validate() error {
+	_, err := newDateFormat(a.Timezone, a.DateFormat)
+	return errors.Join(err, validCommentFormat(a.CommentFormat))
+}
+
+// validCommentFormat checks a comment_format argument. Activity comments carry
+// no plain rendition, unlike descriptions.
+func validCommentFormat(format string) error {
+	switch format {
+	case "", "raw", "html":
+		return nil
+	}
+	return fmt.Errorf("unknown comment_format %q, expected raw or html", format)
+}
+
+type GetWorkPackageFromURLArguments struct {
+	ConnectionArguments
+	URL               string `json:"url" jsonschema:"required,description=Link to a work package as copied from the OpenProject UI,example=https://openproject.example.com/projects/demo/work_packages/42/activity"`
+	DescriptionFormat string `json:"description_format,omitempty" jsonschema:"enum=raw,enum=html,enum=plain,description=Format of the returned description (default raw)"`
+	CommentFormat     string `json:"comment_format,omitempty" jsonschema:"enum=raw,enum=html,description=Format of the returned comments; html resolves @mentions and work package links to names (default raw)"`
+	OutputFormat      string `json:"output_format,omitempty" jsonschema:"enum=json,enum=markdown,description=Format of the tool output (default json)"`
+	Timezone          string `json:"timezone,omitempty" jsonschema:"description=IANA time zone timestamps are converted to (default as returned by OpenProject),example=Europe/Berlin"`
+	DateFormat        string `json:"date_format,omitempty" jsonschema:"description=Go time layout for dates and timestamps (default ISO 8601),example=2006-01-02 15:04"`
+}
+
+type ListWorkPackagesArguments struct {
+	ConnectionArguments
+	ProjectID int    `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+	PageSize  int    `json:"page_size,omitempty" jsonschema:"description=Number of work packages fetched per page (default 20),minimum=1"`
+	Offset    int    `json:"offset,omitempty" jsonschema:"description=1-based page number; when set only that page is returned together with total and count,minimum=1"`
+	SortBy    string `json:"sort_by,omitempty" jsonschema:"description=Sort field prefixed with - for descending order; separate several fields with commas,example=-updatedAt,example=priority\\,-updatedAt"`
+}
+
+func (a ListWorkPackagesArguments) validate() error {
+	if a.PageSize < 0 || a.Offset < 0 {
+		return fmt.Errorf("page_size and offset must not be negative")
+	}
+	return positiveID("project_id", a.ProjectID)
+}
+
+type CreateWorkPackageArguments struct {
+	ConnectionArguments
+	ProjectID   int    `json:"project_id" jsonschema:"required,description=Project ID of OpenProject (see list_projects),example=7"`
+	Subject     string `json:"subject" jsonschema:"required,description=Subject (title) of the new work package,example=Fix login redirect"`
+	TypeID      int    `json:"type_id" jsonschema:"required,description=Work package type ID such as Task or Bug (see list_types)"`
+	Description string `json:"description,omitempty" jsonschema:"description=Description in markdown"`
+}
+
+func (a CreateWorkPackageArguments) validate() error {
+	return errors.Join(
+		positiveID("project_id", a.ProjectID),
+		positiveID("type_id", a.TypeID),
+	)
+}
+
+type CopyWorkPackageArguments struct {
+	ConnectionArguments
+	WorkPackageID int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of the template to copy,example=42"`
+	ProjectID     int    `json:"project_id" jsonschema:"required,description=Project ID the copy is created in (see list_projects),example=7"`
+	SubjectPrefix string `json:"subject_prefix,omitempty" jsonschema:"description=Text put in front of the copied subject,example=[Sprint 12]"`
+}
+
+func (a CopyWorkPackageArguments) validate() error {
+	return errors.Join(
+		positiveID("work_package_id", a.WorkPackageID),
+		positiveID("project_id", a.ProjectID),
+	)
+}
+
+type UpdateWorkPackageStatusArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	StatusID      int    `json:"status_id,omitempty" jsonschema:"description=ID of the new status (see list_statuses)"`
+	StatusName    string `json:"status_name,omitempty" jsonschema:"description=Name of the new status; used when status_id is not given,example=In progress"`
+}
+
+func (a UpdateWorkPackageStatusArguments) validate() error {
+	return errors.Join(
+		positiveID("work_package_id", a.WorkPackageID),
+		optionalID("status_id", a.StatusID),
+	)
+}
+
+type CommentWorkPackageArguments struct {
+	ConnectionArguments
+	WorkPackageID int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	Comment       string `json:"comment" jsonschema:"required,description=Comment text in markdown"`
+}
+
+func (a CommentWorkPackageArguments) validate() error {
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+type SearchWorkPackagesArguments struct {
+	ConnectionArguments
+	Query     string `json:"query" jsonschema:"required,description=Text to search for in work package subjects and descriptions,example=login"`
+	ProjectID int    `json:"project_id,omitempty" jsonschema:"description=Restrict the search to this project ID (default all projects)"`
+	SortBy    string `json:"sort_by,omitempty" jsonschema:"description=Sort field prefixed with - for descending order; separate several fields with commas,example=-updatedAt,example=priority\\,-updatedAt"`
+}
+
+func (a SearchWorkPackagesArguments) validate() error {
+	return optionalID("project_id", a.ProjectID)
+}
+
+type FilterWorkPackagesArguments struct {
+	ConnectionArguments
+	ProjectID   int   `json:"project_id,omitempty" jsonschema:"description=Restrict to this project ID (default all projects)"`
+	StatusIDs   []int `json:"status_ids,omitempty" jsonschema:"description=Match any of these status IDs (see list_statuses); default all statuses"`
+	TypeIDs     []int `json:"type_ids,omitempty" jsonschema:"description=Match any of these type IDs (see list_types)"`
+	AssigneeIDs []int `json:"assignee_ids,omitempty" jsonschema:"description=Match any of these assignee user IDs (see list_users)"`
+}
+
+func (a FilterWorkPackagesArguments) validate() error {
+	errs := []error{optionalID("project_id", a.ProjectID)}
+	for _, f := range []struct {
+		name string
+		ids  []int
+	}{{"status_ids", a.StatusIDs}, {"type_ids", a.TypeIDs}, {"assignee_ids", a.AssigneeIDs}} {
+		for _, id := range f.ids {
+			if id <= 0 {
+				errs = append(errs, fmt.Errorf("%s must only contain positive integers, got %d", f.name, id))
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+type MyWorkPackagesArguments struct {
+	ConnectionArguments
+	OpenOnly bool `json:"open_only,omitempty" jsonschema:"description=Only return work packages with an open status"`
+}
+
+type AssignWorkPackageArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	UserID        int `json:"user_id" jsonschema:"required,description=ID of the user to assign (see list_users)\\, or 0 to unassign"`
+}
+
+func (a AssignWorkPackageArguments) validate() error {
+	return errors.Join(
+		positiveID("work_package_id", a.WorkPackageID),
+		optionalID("user_id", a.UserID),
+	)
+}
+
+type SetProgressArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	PercentDone   int `json:"percent_done" jsonschema:"required,description=Percent complete,minimum=0,maximum=100,example=50"`
+}
+
+func (a SetProgressArguments) validate() error {
+	if a.PercentDone < 0 || a.PercentDone > 100 {
+		return fmt.Errorf("percent_done must be between 0 and 100, got %d", a.PercentDone)
+	}
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+type SetParentArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	ParentID      int `json:"parent_id" jsonschema:"required,description=Work Package ID of the new parent\\, or 0 to make it top-level"`
+}
+
+func (a SetParentArguments) validate() error {
+	if a.ParentID != 0 && a.ParentID == a.WorkPackageID {
+		return fmt.Errorf("a work package cannot be its own parent")
+	}
+	return errors.Join(
+		positiveID("work_package_id", a.WorkPackageID),
+		optionalID("parent_id", a.ParentID),
+	)
+}
+
+type EditWorkPackageArguments struct {
+	ConnectionArguments
+	LockVersionArguments
+	WorkPackageID int    `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	Subject       string `json:"subject,omitempty" jsonschema:"description=New subject; left unchanged when empty"`
+	Description   string `json:"description,omitempty" jsonschema:"description=New description in markdown; left unchanged when empty"`
+}
+
+func (a EditWorkPackageArguments) validate() error {
+	if a.Subject == "" && a.Description == "" {
+		return fmt.Errorf("at least one of subject or description is required")
+	}
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+type DeleteWorkPackageArguments struct {
+	ConnectionArguments
+	WorkPackageID int  `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+	Confirm       bool `json:"confirm" jsonschema:"required,description=Must be true; deleting a work package cannot be undone"`
+}
+
+func (a DeleteWorkPackageArguments) validate() error {
+	if err := positiveID("work_package_id", a.WorkPackageID); err != nil {
+		return err
+	}
+	if !a.Confirm {
+		return fmt.Errorf("deleting work package %d cannot be undone, call again with confirm set to true", a.WorkPackageID)
+	}
+	return nil
+}
+
+type GetWorkPackageChildrenArguments struct {
+	ConnectionArguments
+	WorkPackageID int `json:"work_package_id" jsonschema:"required,description=Work Package ID of OpenProject,example=42"`
+}
+
+func (a GetWorkPackageChildrenArguments) validate() error {
+	return positiveID("work_package_id", a.WorkPackageID)
+}
+
+type WorkPackageResponse struct {
+	ID          int         `json:"id"`
+	LockVersion int         `json:"lockVersion"`
+	Subject     string      `json:"subject"`
+	Description Formattable `json:"description"`
+	StartDate   string      `json:"startDate"`
+	DueDate     string      `json:"dueDate"`
+	UpdatedAt   string      `json:"updatedAt"`
+	// ISO 8601 durations, e.g. "PT2H30M"; null when unset
+	EstimatedTime  string `json:"estimatedTime"`
+	SpentTime      string `json:"spentTime"`
+	PercentageDone int    `json:"percentageDone"`
+	Links          struct {
+		Status   Link `json:"status"`
+		Assignee struct {
+			Title string `json:"title"`
+		} `json:"assignee"`
+		Priority Link `json:"priority"`
+		Version  struct {
+			Title string `json:"title"`
+		} `json:"version"`
+		Type     Link   `json:"type"`
+		Project  Link   `json:"project"`
+		Category Link   `json:"category"`
+		Schema   Link   `json:"schema"`
+		Parent   Link   `json:"parent"`
+		Children []Link `json:"children"`
+	} `json:"_links"`
+	Embedded struct {
+		Attachments struct {
+			Embedded struct {
+				Elements []AttachmentResponse `json:"elements"`
+			} `json:"_embedded"`
+		} `json:"attachments"`
+		Activities struct {
+			Embedded struct {
+				Elements []ActivityResponse `json:"elements"`
+			} `json:"_embedded"`
+		} `json:"activities"`
+	} `json:"_embedded"`
+	// customFieldN values, keyed by property name; filled by UnmarshalJSON
+	CustomFields map[string]json.RawMessage `json:"-"`
+}
+
+func (w *WorkPackageResponse) halType() string { return "WorkPackage" }
+
+// UnmarshalJSON decodes the typed fields and additionally keeps the
+// customFieldN properties, which differ per OpenProject instance. Linked
+// custom fields (users, versions, list options) are reduced to their titles.
+func (w *WorkPackageResponse) UnmarshalJSON(data []byte) error {
+	type plain WorkPackageResponse
+	if err := json.Unmarshal(data, (*plain)(w)); err != nil {
+		return err
+	}
+	var fields map[string]json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return err
+	}
+	var links map[string]json.RawMessage
+	if raw, ok := fields["_links"]; ok {
+		if err := json.Unmarshal(raw, &links); err != nil {
+			return err
+		}
+	}
+
+	w.CustomFields = nil
+	for name, value := range fields {
+		if strings.HasPrefix(name, "customField") {
+			if w.CustomFields == nil {
+				w.CustomFields = make(map[string]json.RawMessage)
+			}
+			w.CustomFields[name] = value
+		}
+	}
+	for name, value := range links {
+		if !strings.HasPrefix(name, "customField") {
+			continue
+		}
+		// single-valued links are an object, multi-valued ones an array
+		var titles any
+		var link Link
+		if err := json.Unmarshal(value, &link); err == nil {
+			if link.Href != "" {
+				titles = link.Title
+			}
+		} else {
+			var list []Link
+			if err := json.Unmarshal(value, &list); err != nil {
+				return fmt.Errorf("custom field %s: %w", name, err)
+			}
+			names := make([]string, 0, len(list))
+			for _, l := range list {
+				names = append(names, l.Title)
+			}
+			titles = names
+		}
+		encoded, err := json.Marshal(titles)
+		if err != nil {
+			return err
+		}
+		if w.CustomFields == nil {
+			w.CustomFields = make(map[string]json.RawMessage)
+		}
+		w.CustomFields[name] = encoded
+	}
+	return nil
+}
+
+type AttachmentResponse struct {
+	ID          int    `json:"id"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	FileSize    int64  `json:"fileSize"`
+	Links       struct {
+		DownloadLocation struct {
+			Href string `json:"href"`
+		} `json:"downloadLocation"`
+	} `json:"_links"`
+}
+
+type ActivityResponse struct {
+	ID        int         `json:"id"`
+	CreatedAt string      `json:"createdAt"`
+	Comment   Formattable `json:"comment"`
+	// field changes, e.g. "Status changed from New to In progress"
+	Details []Formattable `json:"details"`
+	Links   struct {
+		User struct {
+			Title string `json:"title"`
+		} `json:"user"`
+	} `json:"_links"`
+}
+
+type MinimalWorkPackage struct {
+	ID          int    `json:"id"`
+	LockVersion int    `json:"lockVersion"`
+	Subject     string `json:"subject"`
+	Type        string `json:"type,omitempty"`
+	Project     string `json:"project,omitempty"`
+	Status      string `json:"status"`
+	Assignee    string `json:"assignee"`
+	Priority    string `json:"priority,omitempty"`
+	Version     string `json:"version,omitempty"`
+	Category    string `json:"category,omitempty"`
+	Description string `json:"description"`
+	ParentID    int    `json:"parentId,omitempty"`
+	StartDate   string `json:"startDate,omitempty"`
+	DueDate     string `json:"dueDate,omitempty"`
+	// percent complete, 0-100
+	PercentageDone int                  `json:"percentageDone"`
+	EstimatedHours *float64             `json:"estimatedHours,omitempty"`
+	SpentHours     *float64             `json:"spentHours,omitempty"`
+	Evidence       string               `json:"evidence,omitempty"`
+	Attachments    []Attachment         `json:"attachments,omitempty"`
+	Comments       []WorkPackageComment `json:"comments,omitempty"`
+	// customFieldN -> value, as OpenProject returns it (titles for linked fields)
+	CustomFields map[string]json.RawMessage `json:"customFields,omitempty"`
+}
+
+type Attachment struct {
+	ID          int    `json:"id"`
+	FileName    string `json:"fileName"`
+	ContentType string `json:"contentType"`
+	FileSize    int64  `json:"fileSize"`
+	Href        string `json:"href"`
+}
+
+type WorkPackageComment struct {
+	Author    string `json:"author"`
+	CreatedAt string `json:"createdAt"`
+	Comment   string `json:"comment"`
+}
+
+// HAL link, e.g. {"href": "/api/v3/types/1", "title": "Task"}
+type Link struct {
+	Href  string `json:"href"`
+	Title string `json:"title,omitempty"`
+}
+
+// ID returns the numeric ID at the end of the link's href, or 0 if there is none
+func (l Link) ID() int {
+	id, err := strconv.Atoi(l.Href[strings.LastIndex(l.Href, "/")+1:])
+	if err != nil {
+		return 0
+	}
+	return id
+}
+
+// MarshalJSON sends an empty href as null, which is how OpenProject clears a link
+func (l Link) MarshalJSON() ([]byte, error) {
+	if l.Href == "" {
+		return []byte(`{"href":null}`), nil
+	}
+	return json.Marshal(struct {
+		Href string `json:"href"`
+	}{l.Href})
+}
+
+// Formatted text as returned by OpenProject: markdown source plus its renditions
+type Formattable struct {
+	Raw   string `json:"raw"`
+	Html  string `json:"html,omitempty"`
+	Plain string `json:"plain,omitempty"`
+}
+
+// Text returns the variant selected by format ("raw", "html" or "plain"; empty means raw)
+func (f Formattable) Text(format string) (string, error) {
+	switch format {
+	case "", "raw":
+		return f.Raw, nil
+	case "html":
+		return f.Html, nil
+	case "plain":
+		return f.Plain, nil
+	}
+	return "", fmt.Errorf("unknown format %q, expected raw, html or plain", format)
+}
+
+// LockVersionArguments is embedded in the arguments of tools that update a work package
+type LockVersionArguments struct {
+	LockVersion *int `json:"lock_version,omitempty" jsonschema:"description=lockVersion from an earlier read; the update then fails if the work package changed since (default the current version)"`
+}
+
+// Request body for creating or updating a work package
+type WorkPackagePayload struct {
+	LockVersion *int         `json:"lockVersion,omitempty"`
+	Subject     string       `json:"subject,omitempty"`
+	Description *Formattable `json:"description,omitempty"`
+	// pointer so that 0% can be sent
+	PercentageDone *int             `json:"percentageDone,omitempty"`
+	Links          map[string]*Link `json:"_links,omitempty"`
+	// customFieldN properties and links, merged in by MarshalJSON
+	CustomFields     map[string]any `json:"-"`
+	CustomFieldLinks map[string]any `json:"-"`
+}
+
+// MarshalJSON adds the custom fields next to the typed properties and links
+func (p WorkPackagePayload) MarshalJSON() ([]byte, error) {
+	type plain WorkPackagePayload
+	data, err := json.Marshal(plain(p))
+	if err != nil || (len(p.CustomFields) == 0 && len(p.CustomFieldLinks) == 0) {
+		return data, err
+	}
+	var fields map[string]any
+	if err := json.Unmarshal(data, &fields); err != nil {
+		return nil, err
+	}
+	for name, value := range p.CustomFields {
+		fields[name] = value
+	}
+	if len(p.CustomFieldLinks) > 0 {
+		links, _ := fields["_links"].(map[string]any)
+		if links == nil {
+			links = make(map[string]any)
+		}
+		for name, value := range p.CustomFieldLinks {
+			links[name] = value
+		}
+		fields["_links"] = links
+	}
+	return json.Marshal(fields)
+}
+
+// Short form used by listing tools
+type WorkPackageSummary struct {
+	ID       int    `json:"id"`
+	Subject  string `json:"subject"`
+	Status   string `json:"status"`
+	Assignee string `json:"assignee"`
+}
+
+func (c *openProjectClient) getWorkPackage(ctx context.Context, arguments CurlToolArguments) (*mcp_golang.ToolResponse, error) {
+	if arguments.OutputFormat != "" && arguments.OutputFormat != "json" && arguments.OutputFormat != "markdown" {
+		return nil, fmt.Errorf("unknown output_format %q, expected json or markdown", arguments.OutputFormat)
+	}
+	if arguments.Raw {
+		var raw json.RawMessage
+		if err := c.getCached(ctx, fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID), &raw); err != nil {
+			return nil, err
+		}
+		var pretty bytes.Buffer
+		if err := json.Indent(&pretty, raw, "", "  "); err != nil {
+			return nil, err
+		}
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(pretty.String())), nil
+	}
+	wp, err := c.fetchMinimalWorkPackage(ctx, arguments)
+	if err != nil {
+		return nil, err
+	}
+	if arguments.OutputFormat == "markdown" {
+		// MCP text content has no MIME type. An embedded text/markdown resource would
+		// declare it, but mcp-golang v0.11 serializes resources without the "resource"
+		// wrapper the spec requires, so clients could not read them.
+		return mcp_golang.NewToolResponse(mcp_golang.NewTextContent(renderWorkPackageMarkdown(wp))), nil
+	}
+	return jsonResponse(wp)
+}
+
+// getWorkPackageFromURL fetches the work package from the instance the link
+// points to, so a link from another configured instance does not silently
+// return whatever has the same ID on the default one
+func (c *openProjectClient) getWorkPackageFromURL(ctx context.Context, arguments GetWorkPackageFromURLArguments) (*mcp_golang.ToolResponse, error) {
+	u, err := url.Parse(strings.TrimSpace(arguments.URL))
+	if err != nil {
+		return nil, fmt.Errorf("invalid url: %w", err)
+	}
+	id, err := workPackageIDFromURL(u)
+	if err != nil {
+		return nil, err
+	}
+	conn, err := c.clientForURL(u, arguments.ConnectionArguments)
+	if err != nil {
+		return nil, err
+	}
+	return conn.getWorkPackage(ctx, CurlToolArguments{
+		WorkPackageID:     id,
+		DescriptionFormat: arguments.DescriptionFormat,
+		CommentFormat:     arguments.CommentFormat,
+		OutputFormat:      arguments.OutputFormat,
+		Timezone:          arguments.Timezone,
+		DateFormat:        arguments.DateFormat,
+	})
+}
+
+// clientForURL returns c when the link belongs to its base URL (or has no host)
+// and otherwise, unless an instance was named explicitly, the client of the
+// configured instance the link belongs to
+func (c *openProjectClient) clientForURL(u *url.URL, conn ConnectionArguments) (*openProjectClient, error) {
+	if u.Host == "" || underBaseURL(u, c.baseURL) {
+		return c, nil
+	}
+	if conn.Instance == "" {
+		names := make([]string, 0, len(c.instances))
+		for name := range c.instances {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			if underBaseURL(u, c.instances[name].baseURL) {
+				conn.Instance = name
+				return c.forConnection(conn)
+			}
+		}
+	}
+	if conn.Instance != "" {
+		return nil, fmt.Errorf("%s is not a link to instance %q (%s)", u.Redacted(), conn.Instance, c.baseURL)
+	}
+	return nil, fmt.Errorf("%s is not a link to %s or any instance in the config file", u.Redacted(), c.baseURL)
+}
+
+// underBaseURL reports whether u has the host of baseURL and lies below its path
+func underBaseURL(u *url.URL, baseURL string) bool {
+	base, err := url.Parse(baseURL)
+	if err != nil || !strings.EqualFold(u.Host, base.Host) {
+		return false
+	}
+	return u.Path == base.Path || strings.HasPrefix(u.Path, strings.TrimSuffix(base.Path, "/")+"/")
+}
+
+// workPackageIDFromURL extracts the ID from the work package links of the UI:
+// /work_packages/42, /projects/demo/work_packages/42/activity,
+// /projects/demo/work_packages/details/42/overview (split view) and /wp/42
+func workPackageIDFromURL(u *url.URL) (int, error) {
+	segments := strings.Split(strings.Trim(u.Path, "/"), "/")
+	for i := 0; i < len(segments)-1; i++ {
+		if segments[i] != "work_packages" && segments[i] != "wp" {
+			continue
+		}
+		next := segments[i+1]
+		if next == "details" && i+2 < len(segments) {
+			next = segments[i+2]
+		}
+		if id, err := strconv.Atoi(next); err == nil && id > 0 {
+			return id, nil
+		}
+	}
+	return 0, fmt.Errorf("no work package ID found in %q", u.Redacted())
+}
+
+// fetchMinimalWorkPackage fetches a work package and projects it as the tool output
+func (c *openProjectClient) fetchMinimalWorkPackage(ctx context.Context, arguments CurlToolArguments) (*MinimalWorkPackage, error) {
+	var wpResp WorkPackageResponse
+	if err := c.getCached(ctx, fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID), &wpResp); err != nil {
+		return nil, err
+	}
+	return toMinimalWorkPackage(&wpResp, arguments)
+}
+
+// durationHours parses an optional ISO 8601 duration, nil when it is unset
+func durationHours(duration string) (*float64, error) {
+	if duration == "" {
+		return nil, nil
+	}
+	hours, err := parseISODuration(duration)
+	if err != nil {
+		return nil, err
+	}
+	return &hours, nil
+}
+
+// toMinimalWorkPackage projects the HAL response onto the fields the tools return
+func toMinimalWorkPackage(wpResp *WorkPackageResponse, arguments CurlToolArguments) (*MinimalWorkPackage, error) {
+	dates, err := newDateFormat(arguments.Timezone, arguments.DateFormat)
+	if err != nil {
+		return nil, err
+	}
+	description, err := wpResp.Description.Text(arguments.DescriptionFormat)
+	if err != nil {
+		return nil, err
+	}
+	if arguments.CleanDescription {
+		description = cleanDescription(description)
+	}
+
+	wp := &MinimalWorkPackage{
+		ID:             wpResp.ID,
+		LockVersion:    wpResp.LockVersion,
+		Subject:        wpResp.Subject,
+		Type:           wpResp.Links.Type.Title,
+		Project:        wpResp.Links.Project.Title,
+		Status:         wpResp.Links.Status.Title,
+		Assignee:       wpResp.Links.Assignee.Title,
+		Priority:       wpResp.Links.Priority.Title,
+		Version:        wpResp.Links.Version.Title,
+		Category:       wpResp.Links.Category.Title,
+		Description:    description,
+		ParentID:       wpResp.Links.Parent.ID(),
+		StartDate:      dates.date(wpResp.StartDate),
+		DueDate:        dates.date(wpResp.DueDate),
+		PercentageDone: wpResp.PercentageDone,
+		CustomFields:   wpResp.CustomFields,
+	}
+	if wp.EstimatedHours, err = durationHours(wpResp.EstimatedTime); err != nil {
+		return nil, err
+	}
+	if wp.SpentHours, err = durationHours(wpResp.SpentTime); err != nil {
+		return nil, err
+	}
+	// Lấy evidence (ảnh đầu tiên nếu có), giữ lại để tương thích ngược
+	if len(wpResp.Embedded.Attachments.Embedded.Elements) > 0 {
+		first := wpResp.Embedded.Attachments.Embedded.Elements[0]
+		wp.Evidence = first.Links.DownloadLocation.Href
+	}
+	for _, attachment := range wpResp.Embedded.Attachments.Embedded.Elements {
+		wp.Attachments = append(wp.Attachments, toAttachment(attachment))
+	}
+	// Lấy tất cả comment, theo thứ tự API trả về
+	for _, activity := range wpResp.Embedded.Activities.Embedded.Elements {
+		if activity.Comment.Raw == "" {
+			continue
+		}
+		comment, err := activity.Comment.Text(arguments.CommentFormat)
+		if err != nil {
+			return nil, err
+		}
+		wp.Comments = append(wp.Comments, WorkPackageComment{
+			Author:    activity.Links.User.Title,
+			CreatedAt: dates.timestamp(activity.CreatedAt),
+			Comment:   comment,
+		})
+	}
+	return wp, nil
+}
+
+// listWorkPackages lists the work packages of a project whatever their status;
+// closed ones are included in the listing and in total and count.
+func (c *openProjectClient) listWorkPackages(ctx context.Context, arguments ListWorkPackagesArguments) (*mcp_golang.ToolResponse, error) {
+	pageSize := arguments.PageSize
+	if pageSize == 0 {
+		pageSize = 20
+	}
+	// without an explicit empty filter OpenProject only returns open work packages
+	path := fmt.Sprintf("/api/v3/projects/%d/work_packages?filters=%s&pageSize=%d", arguments.ProjectID, url.QueryEscape("[]"), pageSize)
+	if arguments.SortBy != "" {
+		sortBy, err := encodeSortBy(arguments.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		path += "&sortBy=" + sortBy
+	}
+	if arguments.Offset > 0 {
+		var collection CollectionPage[WorkPackageResponse]
+		if err := c.getSelected(ctx, fmt.Sprintf("%s&offset=%d", path, arguments.Offset), workPackageSummarySelect, &collection); err != nil {
+			return nil, err
+		}
+		return jsonResponse(WorkPackagePage{
+			Total:        collection.Total,
+			Count:        collection.Count,
+			Offset:       collection.Offset,
+			PageSize:     collection.PageSize,
+			WorkPackages: summarizeWorkPackages(collection.Embedded.Elements),
+		})
+	}
+	elements, err := fetchAll[WorkPackageResponse](ctx, c, path)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(summarizeWorkPackages(elements))
+}
+
+// WorkPackagePage is a single page of a work package listing
+type WorkPackagePage struct {
+	Total        int                  `json:"total"`
+	Count        int                  `json:"count"`
+	Offset       int                  `json:"offset"`
+	PageSize     int                  `json:"pageSize"`
+	WorkPackages []WorkPackageSummary `json:"workPackages"`
+}
+
+// workPackageSummarySelect are the properties of a work package page that summarizeWorkPackages reads
+var workPackageSummarySelect = []string{"total", "count", "pageSize", "offset", "elements/id", "elements/subject", "elements/status", "elements/assignee"}
+
+func summarizeWorkPackages(elements []WorkPackageResponse) []WorkPackageSummary {
+	summaries := make([]WorkPackageSummary, 0, len(elements))
+	for _, wpResp := range elements {
+		summaries = append(summaries, WorkPackageSummary{
+			ID:       wpResp.ID,
+			Subject:  wpResp.Subject,
+			Status:   wpResp.Links.Status.Title,
+			Assignee: wpResp.Links.Assignee.Title,
+		})
+	}
+	return summaries
+}
+
+func (c *openProjectClient) createWorkPackage(ctx context.Context, arguments CreateWorkPackageArguments) (*mcp_golang.ToolResponse, error) {
+	payload := WorkPackagePayload{
+		Subject: arguments.Subject,
+		Links: map[string]*Link{
+			"type": {Href: fmt.Sprintf("/api/v3/types/%d", arguments.TypeID)},
+		},
+	}
+	if arguments.Description != "" {
+		payload.Description = &Formattable{Raw: arguments.Description}
+	}
+
+	var wpResp WorkPackageResponse
+	path := fmt.Sprintf("/api/v3/projects/%d/work_packages", arguments.ProjectID)
+	if err := c.do(ctx, "POST", path, payload, &wpResp); err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID      int    `json:"id"`
+		Subject string `json:"subject"`
+	}{wpResp.ID, wpResp.Subject})
+}
+
+// copyWorkPackage creates a work package in the target project with the source's
+// subject, description, type and priority. The type must be enabled in the target project.
+func (c *openProjectClient) copyWorkPackage(ctx context.Context, arguments CopyWorkPackageArguments) (*mcp_golang.ToolResponse, error) {
+	var source WorkPackageResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID), &source); err != nil {
+		return nil, err
+	}
+	payload := WorkPackagePayload{
+		Subject: arguments.SubjectPrefix + source.Subject,
+		Links: map[string]*Link{
+			"type": {Href: source.Links.Type.Href},
+		},
+	}
+	if source.Links.Priority.Href != "" {
+		payload.Links["priority"] = &Link{Href: source.Links.Priority.Href}
+	}
+	if source.Description.Raw != "" {
+		payload.Description = &Formattable{Raw: source.Description.Raw}
+	}
+
+	var created WorkPackageResponse
+	path := fmt.Sprintf("/api/v3/projects/%d/work_packages", arguments.ProjectID)
+	if err := c.do(ctx, "POST", path, payload, &created); err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID      int    `json:"id"`
+		Subject string `json:"subject"`
+	}{created.ID, created.Subject})
+}
+
+// patchWorkPackage applies payload using the work package's current lockVersion.
+// If the version turns out to be stale (409), it is refetched and the patch retried once.
+// A lockVersion already set on payload is sent as is, without fetching or retrying,
+// so that a conflict with the caller's read is reported.
+func (c *openProjectClient) patchWorkPackage(ctx context.Context, id int, payload WorkPackagePayload) (*WorkPackageResponse, error) {
+	path := fmt.Sprintf("/api/v3/work_packages/%d", id)
+	if payload.LockVersion != nil {
+		var updated WorkPackageResponse
+		if err := c.do(ctx, "PATCH", path, payload, &updated); err != nil {
+			return nil, err
+		}
+		return &updated, nil
+	}
+	var err error
+	for attempt := 0; attempt < 2; attempt++ {
+		var current WorkPackageResponse
+		if err = c.get(ctx, path, &current); err != nil {
+			return nil, err
+		}
+		payload.LockVersion = &current.LockVersion
+
+		var updated WorkPackageResponse
+		err = c.do(ctx, "PATCH", path, payload, &updated)
+		if err == nil {
+			return &updated, nil
+		}
+		if !isStatus(err, http.StatusConflict) {
+			return nil, err
+		}
+	}
+	return nil, err
+}
+
+func (c *openProjectClient) updateWorkPackageStatus(ctx context.Context, arguments UpdateWorkPackageStatusArguments) (*mcp_golang.ToolResponse, error) {
+	statusID := arguments.StatusID
+	if statusID == 0 {
+		if arguments.StatusName == "" {
+			return nil, fmt.Errorf("either status_id or status_name is required")
+		}
+		var err error
+		if statusID, err = c.resolveStatusID(ctx, arguments.StatusName); err != nil {
+			return nil, err
+		}
+	}
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, WorkPackagePayload{
+		LockVersion: arguments.LockVersion,
+		Links: map[string]*Link{
+			"status": {Href: fmt.Sprintf("/api/v3/statuses/%d", statusID)},
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID     int    `json:"id"`
+		Status string `json:"status"`
+	}{updated.ID, updated.Links.Status.Title})
+}
+
+func (c *openProjectClient) commentWorkPackage(ctx context.Context, arguments CommentWorkPackageArguments) (*mcp_golang.ToolResponse, error) {
+	// raw được gửi nguyên văn, OpenProject tự render markdown
+	payload := struct {
+		Comment Formattable `json:"comment"`
+	}{Formattable{Raw: arguments.Comment}}
+
+	var activity ActivityResponse
+	path := fmt.Sprintf("/api/v3/work_packages/%d/activities", arguments.WorkPackageID)
+	if err := c.do(ctx, "POST", path, payload, &activity); err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID      int    `json:"id"`
+		Comment string `json:"comment"`
+	}{activity.ID, activity.Comment.Html})
+}
+
+func (c *openProjectClient) searchWorkPackages(ctx context.Context, arguments SearchWorkPackagesArguments) (*mcp_golang.ToolResponse, error) {
+	filters := []Filter{newFilter("search", "**", arguments.Query)}
+	if arguments.ProjectID != 0 {
+		filters = append(filters, newFilter("project", "=", strconv.Itoa(arguments.ProjectID)))
+	}
+	query, err := encodeFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/api/v3/work_packages?filters=" + query
+	if arguments.SortBy != "" {
+		sortBy, err := encodeSortBy(arguments.SortBy)
+		if err != nil {
+			return nil, err
+		}
+		path += "&sortBy=" + sortBy
+	}
+
+	elements, err := fetchAll[WorkPackageResponse](ctx, c, path)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(summarizeWorkPackages(elements))
+}
+
+// filterWorkPackages combines the given ID lists into one filter per attribute;
+// an attribute matches any of its IDs and all attributes must match
+func (c *openProjectClient) filterWorkPackages(ctx context.Context, arguments FilterWorkPackagesArguments) (*mcp_golang.ToolResponse, error) {
+	filters := []Filter{}
+	for _, f := range []struct {
+		name string
+		ids  []int
+	}{
+		{"status", arguments.StatusIDs},
+		{"type", arguments.TypeIDs},
+		{"assignee", arguments.AssigneeIDs},
+	} {
+		if len(f.ids) == 0 {
+			continue
+		}
+		values := make([]string, 0, len(f.ids))
+		for _, id := range f.ids {
+			values = append(values, strconv.Itoa(id))
+		}
+		filters = append(filters, newFilter(f.name, "=", values...))
+	}
+	query, err := encodeFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+
+	path := "/api/v3/work_packages"
+	if arguments.ProjectID != 0 {
+		path = fmt.Sprintf("/api/v3/projects/%d/work_packages", arguments.ProjectID)
+	}
+	elements, err := fetchAll[WorkPackageResponse](ctx, c, path+"?filters="+query)
+	if err != nil {
+		return nil, err
+	}
+	workPackages := make([]*MinimalWorkPackage, 0, len(elements))
+	for i := range elements {
+		wp, err := toMinimalWorkPackage(&elements[i], CurlToolArguments{})
+		if err != nil {
+			return nil, err
+		}
+		workPackages = append(workPackages, wp)
+	}
+	return jsonResponse(workPackages)
+}
+
+// myWorkPackages lists the work packages assigned to the API key's user,
+// using OpenProject's "me" placeholder so the caller needs no user ID
+func (c *openProjectClient) myWorkPackages(ctx context.Context, arguments MyWorkPackagesArguments) (*mcp_golang.ToolResponse, error) {
+	filters := []Filter{newFilter("assignee", "=", "me")}
+	if arguments.OpenOnly {
+		filters = append(filters, newFilter("status", "o"))
+	}
+	query, err := encodeFilters(filters)
+	if err != nil {
+		return nil, err
+	}
+	elements, err := fetchAll[WorkPackageResponse](ctx, c, "/api/v3/work_packages?filters="+query)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(summarizeWorkPackages(elements))
+}
+
+func (c *openProjectClient) assignWorkPackage(ctx context.Context, arguments AssignWorkPackageArguments) (*mcp_golang.ToolResponse, error) {
+	assignee := &Link{}
+	if arguments.UserID != 0 {
+		assignee.Href = fmt.Sprintf("/api/v3/users/%d", arguments.UserID)
+	}
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, WorkPackagePayload{
+		LockVersion: arguments.LockVersion,
+		Links:       map[string]*Link{"assignee": assignee},
+	})
+	if arguments.UserID != 0 && isValidationError(err, "assignee") {
+		return nil, fmt.Errorf("user %d cannot be assigned to work package %d, check that they are a member of its project: %w", arguments.UserID, arguments.WorkPackageID, err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID       int    `json:"id"`
+		Assignee string `json:"assignee"`
+	}{updated.ID, updated.Links.Assignee.Title})
+}
+
+func (c *openProjectClient) getWorkPackageChildren(ctx context.Context, arguments GetWorkPackageChildrenArguments) (*mcp_golang.ToolResponse, error) {
+	var wpResp WorkPackageResponse
+	if err := c.get(ctx, fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID), &wpResp); err != nil {
+		return nil, err
+	}
+	type child struct {
+		ID      int    `json:"id"`
+		Subject string `json:"subject"`
+	}
+	children := make([]child, 0, len(wpResp.Links.Children))
+	for _, link := range wpResp.Links.Children {
+		children = append(children, child{ID: link.ID(), Subject: link.Title})
+	}
+	return jsonResponse(children)
+}
+
+// setProgress sets % complete. Instances that derive progress from status reject
+// the change with a 422, which is passed on as is.
+func (c *openProjectClient) setProgress(ctx context.Context, arguments SetProgressArguments) (*mcp_golang.ToolResponse, error) {
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, WorkPackagePayload{
+		LockVersion:    arguments.LockVersion,
+		PercentageDone: &arguments.PercentDone,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID             int `json:"id"`
+		PercentageDone int `json:"percentageDone"`
+	}{updated.ID, updated.PercentageDone})
+}
+
+func (c *openProjectClient) setParent(ctx context.Context, arguments SetParentArguments) (*mcp_golang.ToolResponse, error) {
+	parent := &Link{}
+	if arguments.ParentID != 0 {
+		parent.Href = fmt.Sprintf("/api/v3/work_packages/%d", arguments.ParentID)
+	}
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, WorkPackagePayload{
+		LockVersion: arguments.LockVersion,
+		Links:       map[string]*Link{"parent": parent},
+	})
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID       int `json:"id"`
+		ParentID int `json:"parentId"`
+	}{updated.ID, updated.Links.Parent.ID()})
+}
+
+func (c *openProjectClient) deleteWorkPackage(ctx context.Context, arguments DeleteWorkPackageArguments) (*mcp_golang.ToolResponse, error) {
+	if err := c.do(ctx, "DELETE", fmt.Sprintf("/api/v3/work_packages/%d", arguments.WorkPackageID), nil, nil); err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID      int    `json:"id"`
+		Deleted bool   `json:"deleted"`
+		Message string `json:"message"`
+	}{arguments.WorkPackageID, true, fmt.Sprintf("work package %d deleted", arguments.WorkPackageID)})
+}
+
+func (c *openProjectClient) editWorkPackage(ctx context.Context, arguments EditWorkPackageArguments) (*mcp_golang.ToolResponse, error) {
+	payload := WorkPackagePayload{LockVersion: arguments.LockVersion, Subject: arguments.Subject}
+	if arguments.Description != "" {
+		payload.Description = &Formattable{Raw: arguments.Description}
+	}
+	updated, err := c.patchWorkPackage(ctx, arguments.WorkPackageID, payload)
+	if err != nil {
+		return nil, err
+	}
+	return jsonResponse(struct {
+		ID          int    `json:"id"`
+		Subject     string `json:"subject"`
+		Description string `json:"description"`
+	}{updated.ID, updated.Subject, updated.Description.Raw})
+}
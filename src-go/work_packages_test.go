@@ -0,0 +1,80 @@
+package main
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestWorkPackageFromURL(t *testing.T) {
+	client := &openProjectClient{
+		baseURL:    "https://op.example.com/openproject",
+		basicAuth:  "default-auth",
+		httpClient: &http.Client{},
+		instances: map[string]instance{
+			"other":  {baseURL: "https://other.example.com", basicAuth: "other-auth"},
+			"second": {baseURL: "https://op.example.com/second", basicAuth: "second-auth"},
+		},
+	}
+	authByBase := map[string]string{
+		"https://op.example.com/openproject": "default-auth",
+		"https://other.example.com":          "other-auth",
+		"https://op.example.com/second":      "second-auth",
+	}
+	tests := []struct {
+		name     string
+		link     string
+		instance string
+		wantID   int
+		wantBase string
+		wantErr  string
+	}{
+		{name: "relative link", link: "/work_packages/5", wantID: 5, wantBase: "https://op.example.com/openproject"},
+		{name: "base path", link: "https://op.example.com/openproject/work_packages/42", wantID: 42, wantBase: "https://op.example.com/openproject"},
+		{name: "activity tab", link: "https://op.example.com/openproject/projects/demo/work_packages/42/activity", wantID: 42, wantBase: "https://op.example.com/openproject"},
+		{name: "split view", link: "https://op.example.com/openproject/projects/demo/work_packages/details/43/overview", wantID: 43, wantBase: "https://op.example.com/openproject"},
+		{name: "short link and host case", link: "https://OP.example.com/openproject/wp/7", wantID: 7, wantBase: "https://op.example.com/openproject"},
+		{name: "other instance", link: "https://other.example.com/work_packages/5", wantID: 5, wantBase: "https://other.example.com"},
+		{name: "instance on the same host", link: "https://op.example.com/second/projects/demo/work_packages/9/activity", wantID: 9, wantBase: "https://op.example.com/second"},
+		{name: "named instance", link: "https://other.example.com/work_packages/5", instance: "other", wantID: 5, wantBase: "https://other.example.com"},
+		{name: "foreign host", link: "http://other/work_packages/5", wantErr: "is not a link to https://op.example.com/openproject or any instance"},
+		{name: "outside the base path", link: "https://op.example.com/work_packages/5", wantErr: "is not a link to"},
+		{name: "not the named instance", link: "https://op.example.com/openproject/work_packages/5", instance: "other", wantErr: `is not a link to instance "other"`},
+		{name: "no ID", link: "https://op.example.com/openproject/projects/demo/work_packages", wantErr: "no work package ID"},
+		{name: "non-numeric ID", link: "https://op.example.com/openproject/work_packages/new", wantErr: "no work package ID"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			u, err := url.Parse(tt.link)
+			if err != nil {
+				t.Fatal(err)
+			}
+			// bind has already applied the connection arguments to the client
+			conn := ConnectionArguments{Instance: tt.instance}
+			c, err := client.forConnection(conn)
+			if err != nil {
+				t.Fatal(err)
+			}
+			id, err := workPackageIDFromURL(u)
+			if err == nil {
+				c, err = c.clientForURL(u, conn)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("%s: got error %v, want one containing %q", tt.link, err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("%s: %v", tt.link, err)
+			}
+			if id != tt.wantID || c.baseURL != tt.wantBase {
+				t.Errorf("%s: got work package %d on %s, want %d on %s", tt.link, id, c.baseURL, tt.wantID, tt.wantBase)
+			}
+			if want := authByBase[tt.wantBase]; c.basicAuth != want {
+				t.Errorf("%s: got credentials %q, want %q", tt.link, c.basicAuth, want)
+			}
+		})
+	}
+}